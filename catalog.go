@@ -0,0 +1,220 @@
+package errors
+
+import (
+	"sync"
+
+	"github.com/Kviky/errors/models"
+)
+
+// ExtID is the name of the extension member carrying the stable identifier of the problem
+const ExtID = "id"
+
+// Entry describes a single problem type registered in a Catalog
+type Entry struct {
+	// Name is the Go identifier of the problem, e.g. ListingNotFound
+	Name string `json:"name" yaml:"name"`
+	// ID is the stable identifier of the problem, e.g. listing.not_found.
+	// Unlike the title it doesn't change with wording or localization.
+	ID       string `json:"id" yaml:"id"`
+	Title    string `json:"title" yaml:"title"`
+	Detail   string `json:"detail" yaml:"detail"`
+	Status   int32  `json:"status" yaml:"status"`
+	Code     string `json:"code" yaml:"code"`
+	Instance string `json:"instance" yaml:"instance"`
+	// LogLevel overrides the level derived from the status
+	LogLevel Level `json:"logLevel,omitempty" yaml:"logLevel,omitempty"`
+	// Retry overrides the retry metadata derived from the status
+	Retry *Retry `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Guide overrides the documentation derived from the status
+	Guide *Guide `json:"guide,omitempty" yaml:"guide,omitempty"`
+	// Template marks a detail with fmt verbs meant to be filled by NewProblemf
+	Template bool `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
+// Level returns the log level of the entry
+func (e Entry) Level() Level {
+	if e.LogLevel != 0 {
+		return e.LogLevel
+	}
+	return LevelForStatus(e.Status)
+}
+
+// RetryPolicy returns the retry metadata of the entry
+func (e Entry) RetryPolicy() Retry {
+	if e.Retry != nil {
+		return *e.Retry
+	}
+	return RetryForStatus(e.Status)
+}
+
+// Documentation returns the causes and remediation of the entry
+func (e Entry) Documentation() Guide {
+	if e.Guide != nil {
+		return *e.Guide
+	}
+	return GuideForStatus(e.Status)
+}
+
+// Problem creates a new ProblemDetails object from the entry
+func (e Entry) Problem() *models.ProblemDetails {
+	problem := &models.ProblemDetails{
+		Title:    e.Title,
+		Detail:   e.Detail,
+		Status:   e.Status,
+		Code:     e.Code,
+		Instance: e.Instance,
+		Type:     "/",
+	}
+	if e.ID != "" {
+		problem.ProblemDetailsAdditionalProperties = map[string]interface{}{ExtID: e.ID}
+	}
+	return problem
+}
+
+// problemID returns the stable identifier of the problem
+func problemID(problem *models.ProblemDetails) string {
+	if problem == nil {
+		return ""
+	}
+	id, _ := problem.ProblemDetailsAdditionalProperties[ExtID].(string)
+	return id
+}
+
+// systemFailure is used when a catalog doesn't know the requested title
+// and has no SystemFailure entry of its own
+var systemFailure = Entry{
+	Name:     "SystemFailure",
+	ID:       "system.failure",
+	Title:    SystemFailure,
+	Detail:   "We are sorry, but there is an internal problem with the application!",
+	Status:   500,
+	Code:     internalServerError,
+	Instance: InstApi,
+}
+
+// Catalog is a set of problem types addressed by their title or ID.
+// It is safe for concurrent use.
+type Catalog struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+	titles  []string
+	// ids maps IDs to titles
+	ids map[string]string
+}
+
+// DefaultCatalog holds all problems defined by this package
+var DefaultCatalog = NewCatalog(defaultEntries...)
+
+// DefaultEntries returns a copy of the entries defined by this package
+// including duplicates which are merged by the DefaultCatalog
+func DefaultEntries() []Entry {
+	return append([]Entry(nil), defaultEntries...)
+}
+
+// NewCatalog creates a catalog with given entries
+func NewCatalog(entries ...Entry) *Catalog {
+	c := &Catalog{
+		entries: make(map[string]Entry, len(entries)),
+		ids:     make(map[string]string, len(entries)),
+	}
+	c.Register(entries...)
+	return c
+}
+
+// Register adds entries to the catalog. An entry with already registered
+// title replaces the previous definition.
+func (c *Catalog) Register(entries ...Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range entries {
+		if prev, ok := c.entries[e.Title]; !ok {
+			c.titles = append(c.titles, e.Title)
+		} else if prev.ID != "" {
+			delete(c.ids, prev.ID)
+		}
+		c.entries[e.Title] = e
+		if e.ID != "" {
+			c.ids[e.ID] = e.Title
+		}
+	}
+}
+
+// Lookup returns the entry registered under given title or ID
+func (c *Catalog) Lookup(key string) (Entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if e, ok := c.entries[key]; ok {
+		return e, true
+	}
+	e, ok := c.entries[c.ids[key]]
+	return e, ok
+}
+
+// lookupProblem returns the entry of a problem by its ID, or by its title
+// for problems without ID
+func (c *Catalog) lookupProblem(problem *models.ProblemDetails) (Entry, bool) {
+	if id := problemID(problem); id != "" {
+		if e, ok := c.Lookup(id); ok {
+			return e, true
+		}
+	}
+	return c.Lookup(problem.Title)
+}
+
+// Entries returns all entries in the order of registration
+func (c *Catalog) Entries() []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(c.titles))
+	for _, title := range c.titles {
+		entries = append(entries, c.entries[title])
+	}
+	return entries
+}
+
+// strictStatuses are 400 problems of the DefaultCatalog which are
+// served with a more specific status by UseStrictStatuses
+var strictStatuses = map[string]struct {
+	status int32
+	code   string
+}{
+	AlreadyExists:           {409, conflict},
+	CharterHasListings:      {409, conflict},
+	FileExistsAlready:       {409, conflict},
+	NameAlreadyTaken:        {409, conflict},
+	PortAlreadyExists:       {409, conflict},
+	InvalidDates:            {422, unprocessableEntry},
+	MandatoryParamIncorrect: {422, unprocessableEntry},
+}
+
+// UseStrictStatuses re-maps problems reporting conflicts (e.g. AlreadyExists)
+// to 409 Conflict and problems reporting semantically incorrect values
+// (e.g. InvalidDates) to 422 Unprocessable Entity. For compatibility with
+// existing clients they are served as 400 unless this is called.
+func (c *Catalog) UseStrictStatuses() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for title, strict := range strictStatuses {
+		if e, ok := c.entries[title]; ok {
+			e.Status = strict.status
+			e.Code = strict.code
+			c.entries[title] = e
+		}
+	}
+}
+
+// Problem creates ProblemDetails for given title or ID. Unknown keys
+// are reported as SystemFailure.
+func (c *Catalog) Problem(key string) *models.ProblemDetails {
+	if e, ok := c.Lookup(key); ok {
+		return e.Problem()
+	}
+	if e, ok := c.Lookup(SystemFailure); ok {
+		return e.Problem()
+	}
+	return systemFailure.Problem()
+}
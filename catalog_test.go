@@ -0,0 +1,104 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Register(t *testing.T) {
+	catalog := NewCatalog(Entry{Title: BadRequest, Status: http.StatusBadRequest})
+	catalog.Register(
+		Entry{Title: ListingNotFound, Status: http.StatusNotFound},
+		Entry{Title: BadRequest, Status: http.StatusBadRequest, Detail: "Replaced!"},
+	)
+
+	entries := catalog.Entries()
+	Len(t, entries, 2)
+	Equal(t, BadRequest, entries[0].Title)
+	Equal(t, "Replaced!", entries[0].Detail)
+	Equal(t, ListingNotFound, entries[1].Title)
+
+	entry, ok := catalog.Lookup(ListingNotFound)
+	True(t, ok)
+	EqualValues(t, http.StatusNotFound, entry.Status)
+
+	_, ok = catalog.Lookup(UserNotFound)
+	False(t, ok)
+}
+
+func TestCatalog_Problem(t *testing.T) {
+	catalog := NewCatalog(Entry{Title: BadRequest, Status: http.StatusBadRequest, Code: badRequest})
+
+	problem := catalog.Problem(BadRequest)
+	Equal(t, BadRequest, problem.Title)
+	EqualValues(t, http.StatusBadRequest, problem.Status)
+	Equal(t, "/", problem.Type)
+
+	problem = catalog.Problem(ListingNotFound)
+	Equal(t, SystemFailure, problem.Title)
+	EqualValues(t, http.StatusInternalServerError, problem.Status)
+
+	catalog.Register(Entry{Title: SystemFailure, Status: http.StatusInternalServerError, Detail: "Oops!"})
+	problem = catalog.Problem(ListingNotFound)
+	Equal(t, "Oops!", problem.Detail)
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	entries := DefaultCatalog.Entries()
+	Len(t, entries, 59)
+
+	for _, e := range entries {
+		NotEmpty(t, e.Detail, e.Title)
+		if text := http.StatusText(int(e.Status)); text != "" {
+			Equal(t, text, e.Code, e.Title)
+		}
+	}
+}
+
+func TestEntry_Level(t *testing.T) {
+	Equal(t, LevelInfo, Entry{Status: http.StatusNotFound}.Level())
+	Equal(t, LevelWarn, Entry{Status: http.StatusTooManyRequests}.Level())
+	Equal(t, LevelDebug, Entry{Status: http.StatusNotFound, LogLevel: LevelDebug}.Level())
+}
+
+func TestEntry_RetryPolicy(t *testing.T) {
+	True(t, Entry{Status: http.StatusServiceUnavailable}.RetryPolicy().Retryable)
+	False(t, Entry{Status: http.StatusBadRequest}.RetryPolicy().Retryable)
+	True(t, Entry{Status: http.StatusConflict, Retry: &Retry{Retryable: true}}.RetryPolicy().Retryable)
+}
+
+func TestCatalog_UseStrictStatuses(t *testing.T) {
+	catalog := NewCatalog(DefaultCatalog.Entries()...)
+	catalog.UseStrictStatuses()
+
+	problem := catalog.Problem(AlreadyExists)
+	EqualValues(t, http.StatusConflict, problem.Status)
+	Equal(t, conflict, problem.Code)
+
+	problem = catalog.Problem(InvalidDates)
+	EqualValues(t, http.StatusUnprocessableEntity, problem.Status)
+	Equal(t, unprocessableEntry, problem.Code)
+
+	problem = catalog.Problem(BadRequest)
+	EqualValues(t, http.StatusBadRequest, problem.Status)
+
+	problem = DefaultCatalog.Problem(AlreadyExists)
+	EqualValues(t, http.StatusBadRequest, problem.Status)
+}
+
+func TestCatalog_LookupID(t *testing.T) {
+	catalog := NewCatalog(Entry{ID: "listing.not_found", Title: ListingNotFound, Status: 404})
+
+	e, ok := catalog.Lookup("listing.not_found")
+	True(t, ok)
+	Equal(t, ListingNotFound, e.Title)
+	Equal(t, "listing.not_found", catalog.Problem("listing.not_found").ProblemDetailsAdditionalProperties[ExtID])
+
+	catalog.Register(Entry{ID: "listing.missing", Title: ListingNotFound, Status: 404})
+	_, ok = catalog.Lookup("listing.not_found")
+	False(t, ok)
+	_, ok = catalog.Lookup("listing.missing")
+	True(t, ok)
+}
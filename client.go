@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Kviky/errors/models"
+)
+
+// maxProblemSize limits the size of a response body read by FromResponse
+const maxProblemSize = 1 << 20
+
+// statusTitles maps statuses of responses without ProblemDetails body to catalog titles
+var statusTitles = map[int]string{
+	http.StatusBadRequest:            BadRequest,
+	http.StatusUnauthorized:          UnauthorizedAccess,
+	http.StatusForbidden:             ForbiddenResource,
+	http.StatusNotFound:              ResourceNotFound,
+	http.StatusMethodNotAllowed:      MethodNotAllowed,
+	http.StatusConflict:              ResourceConflict,
+	http.StatusGone:                  ResourceGone,
+	http.StatusPreconditionFailed:    PreconditionFailed,
+	http.StatusRequestEntityTooLarge: PayloadTooLarge,
+	http.StatusUnsupportedMediaType:  UnsupportedMediaType,
+	http.StatusUnprocessableEntity:   ValidationFailed,
+	http.StatusPreconditionRequired:  PreconditionRequired,
+	http.StatusTooManyRequests:       CongestionRisk,
+	http.StatusInternalServerError:   SystemFailure,
+	http.StatusServiceUnavailable:    ServiceUnavailable,
+	http.StatusGatewayTimeout:        GatewayTimeout,
+}
+
+// FromResponse turns a 4xx or 5xx response into a Problem error using the DefaultCatalog.
+// It returns nil for other responses.
+func FromResponse(resp *http.Response) error {
+	return DefaultCatalog.FromResponse(resp)
+}
+
+// FromResponse turns a 4xx or 5xx response into a Problem error. A ProblemDetails body
+// is decoded as is, so errors.Is works with titles of the remote service. Other bodies
+// are reported as the catalog problem matching the response status.
+// The body remains readable by the caller.
+func (c *Catalog) FromResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = ioutil.ReadAll(io.LimitReader(resp.Body, maxProblemSize))
+		_ = resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if isJSON(resp.Header.Get("Content-Type")) {
+		details := &models.ProblemDetails{}
+		if err := details.UnmarshalBinary(body); err == nil && details.Title != "" {
+			return c.decoded(details, resp.StatusCode)
+		}
+	}
+
+	return c.fallback(resp.StatusCode, body)
+}
+
+// decoded completes a decoded problem with its catalog entry
+func (c *Catalog) decoded(details *models.ProblemDetails, status int) *Problem {
+	if details.Status == 0 {
+		details.Status = int32(status)
+	}
+	if e, ok := c.lookupProblem(details); ok {
+		if details.Code == "" {
+			details.Code = e.Code
+		}
+		if details.Instance == "" {
+			details.Instance = e.Instance
+		}
+	}
+	return &Problem{ProblemDetails: details}
+}
+
+// fallback creates a problem for a response without ProblemDetails body
+func (c *Catalog) fallback(status int, body []byte) *Problem {
+	var details *models.ProblemDetails
+	if title, ok := statusTitles[status]; ok {
+		details = c.Problem(title)
+	} else {
+		details = &models.ProblemDetails{
+			Title: http.StatusText(status),
+			Code:  http.StatusText(status),
+			Type:  "/",
+		}
+	}
+	details.Status = int32(status)
+
+	if text := strings.TrimSpace(string(body)); text != "" {
+		details.Detail = truncate(text, 512)
+	}
+	return &Problem{ProblemDetails: details}
+}
+
+// truncate cuts text to at most max bytes without splitting a UTF-8 rune
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
+
+// isJSON reports whether the content type is application/json,
+// application/problem+json or other +json type
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func newResponse(status int, contentType, body string) *http.Response {
+	rr := httptest.NewRecorder()
+	rr.Header().Set("Content-Type", contentType)
+	rr.WriteHeader(status)
+	_, _ = rr.WriteString(body)
+	return rr.Result()
+}
+
+func TestFromResponse(t *testing.T) {
+	NoError(t, FromResponse(nil))
+	NoError(t, FromResponse(newResponse(http.StatusOK, "application/json", `{}`)))
+
+	resp := newResponse(http.StatusNotFound, "application/problem+json",
+		`{"title":"Listing not found!","detail":"Listing 42 does not exist!","status":404}`)
+	err := FromResponse(resp)
+	True(t, Is(err, ListingNotFound))
+	True(t, errors.Is(err, NewProblem(ListingNotFound)))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	Equal(t, "Listing 42 does not exist!", p.Detail)
+	Equal(t, notFound, p.Code)
+	Equal(t, InstClient, p.Instance)
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	Contains(t, string(body), "Listing 42")
+}
+
+func TestFromResponse_id(t *testing.T) {
+	resp := newResponse(http.StatusNotFound, "application/json",
+		`{"id":"listing.not_found","title":"Oglas nije pronađen!","status":404}`)
+	err := FromResponse(resp)
+	True(t, Is(err, "listing.not_found"))
+	True(t, errors.Is(err, NewProblem(ListingNotFound)))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	Equal(t, notFound, p.Code)
+}
+
+func TestFromResponse_fallback(t *testing.T) {
+	err := FromResponse(newResponse(http.StatusServiceUnavailable, "text/html", "<h1>Down</h1>"))
+	True(t, Is(err, ServiceUnavailable))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	Equal(t, "<h1>Down</h1>", p.Detail)
+
+	err = FromResponse(newResponse(http.StatusNotFound, "application/json", `{"message":"not found"}`))
+	True(t, Is(err, ResourceNotFound))
+
+	for status, title := range map[int]string{
+		http.StatusConflict:              ResourceConflict,
+		http.StatusGone:                  ResourceGone,
+		http.StatusPreconditionFailed:    PreconditionFailed,
+		http.StatusRequestEntityTooLarge: PayloadTooLarge,
+		http.StatusUnsupportedMediaType:  UnsupportedMediaType,
+		http.StatusUnprocessableEntity:   ValidationFailed,
+		http.StatusPreconditionRequired:  PreconditionRequired,
+	} {
+		err = FromResponse(newResponse(status, "text/plain", ""))
+		True(t, Is(err, title), "status %d", status)
+	}
+
+	err = FromResponse(newResponse(http.StatusTeapot, "application/json", `not json`))
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusTeapot, p.Status)
+	Equal(t, "I'm a teapot", p.Title)
+
+	err = FromResponse(newResponse(http.StatusBadGateway, "application/problem+json", `{"status":502,"title":"Upstream failed!"}`))
+	True(t, Is(err, "Upstream failed!"))
+
+	err = FromResponse(newResponse(http.StatusBadRequest, "application/json", strings.Repeat("a", 1000)))
+	True(t, errors.As(err, &p))
+	Len(t, p.Detail, 512)
+
+	err = FromResponse(newResponse(http.StatusBadRequest, "text/plain", "a"+strings.Repeat("č", 600)))
+	True(t, errors.As(err, &p))
+	Len(t, p.Detail, 511)
+	True(t, utf8.ValidString(p.Detail))
+}
+
+func Test_isJSON(t *testing.T) {
+	True(t, isJSON("application/json"))
+	True(t, isJSON("application/problem+json; charset=utf-8"))
+	False(t, isJSON("text/plain"))
+	False(t, isJSON(""))
+}
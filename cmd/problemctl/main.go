@@ -0,0 +1,340 @@
+// Command problemctl inspects, lints and generates API definitions of the problems catalog.
+//
+//	problemctl list [-status 404] [-instance client] [-code "Not Found"] [-strict-statuses]
+//	problemctl show listing.not_found
+//	problemctl lint [-catalog catalog.yml] [-translations translations.yml] [-strict]
+//	problemctl spec [-format swagger|openapi] [-output yaml|json] [-content-type application/json]
+//	problemctl typescript > problems.ts
+//	problemctl jsonschema > problems.schema.json
+//	problemctl export > catalog.yml
+//	problemctl diff [-json] old.yml [new.yml]
+//
+// lint exits with status 1 when the catalog has errors, or any issues in strict mode.
+// diff exits with status 1 when there are breaking changes.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/Kviky/errors"
+)
+
+const usage = `usage: problemctl <command> [flags]
+
+commands:
+  list        list registered problems
+  show        show the rendered problem of given ID or title
+  lint        lint the catalog
+  spec        generate Swagger 2.0 or OpenAPI 3 responses
+  typescript  generate TypeScript types and constants
+  jsonschema  generate JSON Schema
+  export      export the catalog as YAML
+  diff        compare two exported catalogs
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return list(args[1:], stdout, stderr)
+	case "show":
+		return show(args[1:], stdout, stderr)
+	case "lint":
+		return lint(args[1:], stdout, stderr)
+	case "spec":
+		return spec(args[1:], stdout, stderr)
+	case "typescript":
+		return typescript(args[1:], stdout, stderr)
+	case "jsonschema":
+		return jsonschema(args[1:], stdout, stderr)
+	case "export":
+		return export(args[1:], stdout, stderr)
+	case "diff":
+		return diff(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// newFlagSet creates a flag set reporting errors to stderr
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// defaultCatalog creates a copy of the default catalog
+func defaultCatalog(strictStatuses bool) *errors.Catalog {
+	catalog := errors.NewCatalog(errors.DefaultEntries()...)
+	if strictStatuses {
+		catalog.UseStrictStatuses()
+	}
+	return catalog
+}
+
+func list(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("list", stderr)
+	status := fs.Int("status", 0, "show problems with given status only")
+	instance := fs.String("instance", "", "show problems of given instance only")
+	code := fs.String("code", "", "show problems with given code only")
+	strict := fs.Bool("strict-statuses", false, "list statuses used after UseStrictStatuses")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	catalog := defaultCatalog(*strict)
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tCODE\tINSTANCE\tID\tTITLE")
+	for _, e := range catalog.Entries() {
+		if *status != 0 && int(e.Status) != *status ||
+			*instance != "" && e.Instance != *instance ||
+			*code != "" && e.Code != *code {
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", e.Status, e.Code, e.Instance, e.ID, e.Title)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func show(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("show", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: problemctl show <id|title>")
+		return 2
+	}
+
+	key := fs.Arg(0)
+	if _, ok := errors.DefaultCatalog.Lookup(key); !ok {
+		fmt.Fprintf(stderr, "unknown problem %q\n", key)
+		return 1
+	}
+
+	data, err := json.MarshalIndent(errors.CreateProblemDetails(key), "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintln(stdout, string(data))
+	return 0
+}
+
+func lint(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("lint", stderr)
+	catalogFile := fs.String("catalog", "", "lint a catalog exported by problemctl export instead of the built-in one")
+	file := fs.String("translations", "", "YAML file with translations by language and title")
+	strict := fs.Bool("strict", false, "fail on warnings")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	entries := errors.DefaultEntries()
+	if *catalogFile != "" {
+		var err error
+		if entries, err = readEntries(*catalogFile); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	var translations errors.Translations
+	if *file != "" {
+		data, err := ioutil.ReadFile(*file)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		if err := yaml.Unmarshal(data, &translations); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", *file, err)
+			return 1
+		}
+	}
+
+	issues := errors.Lint(entries, translations)
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue)
+	}
+	if errors.LintFailed(issues, *strict) {
+		return 1
+	}
+	return 0
+}
+
+func spec(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("spec", stderr)
+	format := fs.String("format", "swagger", "swagger for Swagger 2.0 or openapi for OpenAPI 3")
+	output := fs.String("output", "yaml", "yaml or json")
+	contentType := fs.String("content-type", "application/json", "content type of the responses")
+	strict := fs.Bool("strict-statuses", false, "generate statuses used after UseStrictStatuses")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var specFormat errors.SpecFormat
+	switch *format {
+	case "swagger":
+		specFormat = errors.Swagger2
+	case "openapi":
+		specFormat = errors.OpenAPI3
+	default:
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return 2
+	}
+
+	generated := defaultCatalog(*strict).Spec(specFormat, *contentType)
+
+	var data []byte
+	var err error
+	switch *output {
+	case "yaml":
+		data, err = yaml.Marshal(generated)
+	case "json":
+		data, err = json.MarshalIndent(generated, "", "  ")
+		data = append(data, '\n')
+	default:
+		fmt.Fprintf(stderr, "unknown output %q\n", *output)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	_, _ = stdout.Write(data)
+	return 0
+}
+
+func typescript(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("typescript", stderr)
+	strict := fs.Bool("strict-statuses", false, "generate statuses used after UseStrictStatuses")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := defaultCatalog(*strict).WriteTypeScript(stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func jsonschema(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("jsonschema", stderr)
+	strict := fs.Bool("strict-statuses", false, "generate statuses used after UseStrictStatuses")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := json.MarshalIndent(defaultCatalog(*strict).JSONSchema(), "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintln(stdout, string(data))
+	return 0
+}
+
+func export(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("export", stderr)
+	strict := fs.Bool("strict-statuses", false, "export statuses used after UseStrictStatuses")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := yaml.Marshal(defaultCatalog(*strict).Entries())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	_, _ = stdout.Write(data)
+	return 0
+}
+
+// readEntries reads entries of a catalog exported by problemctl export
+func readEntries(file string) ([]errors.Entry, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var entries []errors.Entry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return entries, nil
+}
+
+func diff(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("diff", stderr)
+	asJSON := fs.Bool("json", false, "print changes as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fmt.Fprintln(stderr, "usage: problemctl diff [-json] old.yml [new.yml]")
+		return 2
+	}
+
+	from, err := readEntries(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	// without new.yml the DefaultCatalog of this build of the module is the new version
+	to := errors.DefaultCatalog.Entries()
+	if fs.NArg() == 2 {
+		if to, err = readEntries(fs.Arg(1)); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	changes := errors.Diff(from, to)
+	if *asJSON {
+		if changes == nil {
+			changes = []errors.Change{}
+		}
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		breaking := 0
+		for _, c := range changes {
+			fmt.Fprintln(stdout, c)
+			if c.Breaking {
+				breaking++
+			}
+		}
+		fmt.Fprintf(stdout, "%d changes, %d breaking\n", len(changes), breaking)
+	}
+
+	if errors.HasBreaking(changes) {
+		return 1
+	}
+	return 0
+}
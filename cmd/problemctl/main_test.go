@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors"
+)
+
+func runArgs(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRun_usage(t *testing.T) {
+	status, _, stderr := runArgs()
+	Equal(t, 2, status)
+	Contains(t, stderr, "usage")
+
+	status, _, stderr = runArgs("unknown")
+	Equal(t, 2, status)
+	Contains(t, stderr, `unknown command "unknown"`)
+}
+
+func TestRun_list(t *testing.T) {
+	status, stdout, _ := runArgs("list", "-status", "404", "-instance", "client")
+	Equal(t, 0, status)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	Contains(t, lines[0], "TITLE")
+	Contains(t, stdout, errors.ListingNotFound)
+	NotContains(t, stdout, errors.BadRequest)
+	for _, line := range lines[1:] {
+		True(t, strings.HasPrefix(line, "404"), line)
+	}
+
+	_, stdout, _ = runArgs("list", "-strict-statuses", "-status", "409")
+	Contains(t, stdout, errors.AlreadyExists)
+}
+
+func TestRun_show(t *testing.T) {
+	status, stdout, _ := runArgs("show", errors.ListingNotFound)
+	Equal(t, 0, status)
+	Contains(t, stdout, `"status": 404`)
+
+	status, stdout, _ = runArgs("show", "listing.not_found")
+	Equal(t, 0, status)
+	Contains(t, stdout, `"title": "Listing not found!"`)
+
+	status, _, stderr := runArgs("show", "Nope!")
+	Equal(t, 1, status)
+	Contains(t, stderr, "unknown problem")
+}
+
+func TestRun_lint(t *testing.T) {
+	status, stdout, _ := runArgs("lint", "-strict")
+	Equal(t, 0, status)
+	Empty(t, stdout)
+
+	dir := t.TempDir()
+	catalog := filepath.Join(dir, "catalog.yml")
+	NoError(t, ioutil.WriteFile(catalog, []byte("- id: listing.inactive\n  title: Inactive listing!\n  detail: Listing %v is not active!\n  status: 400\n  code: Bad Request\n"), 0o600))
+
+	status, stdout, _ = runArgs("lint", "-catalog", catalog)
+	Equal(t, 1, status)
+	Contains(t, stdout, "error: Inactive listing! [verbs]")
+
+	file := filepath.Join(dir, "translations.yml")
+	NoError(t, ioutil.WriteFile(file, []byte("hr:\n  \"Listing not found!\":\n    title: Oglas nije pronađen!\n"), 0o600))
+
+	status, stdout, _ = runArgs("lint", "-translations", file)
+	Equal(t, 1, status)
+	Contains(t, stdout, "missing hr translation")
+	NotContains(t, stdout, errors.ListingNotFound+" [translation]")
+}
+
+func TestRun_spec(t *testing.T) {
+	status, stdout, _ := runArgs("spec")
+	Equal(t, 0, status)
+	Contains(t, stdout, "ListingNotFound:")
+	Contains(t, stdout, "$ref: '#/definitions/ProblemDetails'")
+
+	status, stdout, _ = runArgs("spec", "-format", "openapi", "-output", "json")
+	Equal(t, 0, status)
+	Contains(t, stdout, `"#/components/schemas/ProblemDetails"`)
+
+	status, _, stderr := runArgs("spec", "-format", "raml")
+	Equal(t, 2, status)
+	Contains(t, stderr, `unknown format "raml"`)
+}
+
+func TestRun_typescript(t *testing.T) {
+	status, stdout, _ := runArgs("typescript", "-strict-statuses")
+	Equal(t, 0, status)
+	Contains(t, stdout, `AlreadyExists: { id: "resource.already_exists", title: "Already exists!", status: 409 },`)
+}
+
+func TestRun_jsonschema(t *testing.T) {
+	status, stdout, _ := runArgs("jsonschema")
+	Equal(t, 0, status)
+	Contains(t, stdout, `"$schema": "http://json-schema.org/draft-07/schema#"`)
+}
+
+func TestRun_diff(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.yml")
+	current := filepath.Join(dir, "new.yml")
+
+	status, stdout, _ := runArgs("export")
+	Equal(t, 0, status)
+	NoError(t, ioutil.WriteFile(old, []byte(stdout), 0o600))
+
+	status, stdout, _ = runArgs("diff", old)
+	Equal(t, 0, status)
+	Equal(t, "0 changes, 0 breaking\n", stdout)
+
+	_, stdout, _ = runArgs("export", "-strict-statuses")
+	NoError(t, ioutil.WriteFile(current, []byte(stdout), 0o600))
+
+	status, stdout, _ = runArgs("diff", old, current)
+	Equal(t, 1, status)
+	Contains(t, stdout, `BREAKING status Already exists! (resource.already_exists): "400" -> "409"`)
+	Contains(t, stdout, "7 changes, 7 breaking")
+
+	status, stdout, _ = runArgs("diff", "-json", old, current)
+	Equal(t, 1, status)
+	var changes []errors.Change
+	NoError(t, json.Unmarshal([]byte(stdout), &changes))
+	Len(t, changes, 7)
+	Equal(t, errors.ChangeStatus, changes[0].Kind)
+
+	status, _, stderr := runArgs("diff", filepath.Join(dir, "missing.yml"))
+	Equal(t, 1, status)
+	Contains(t, stderr, "missing.yml")
+}
+
+func TestRun_diffDefaultCatalog(t *testing.T) {
+	defer func(c *errors.Catalog) { errors.DefaultCatalog = c }(errors.DefaultCatalog)
+	errors.DefaultCatalog = errors.NewCatalog(errors.DefaultEntries()...)
+	errors.DefaultCatalog.UseStrictStatuses()
+	errors.DefaultCatalog.Register(errors.Entry{ID: "boat.sunk", Title: "Boat sunk!", Status: 410, Code: "Gone"})
+
+	old := filepath.Join(t.TempDir(), "old.yml")
+	_, stdout, _ := runArgs("export")
+	NoError(t, ioutil.WriteFile(old, []byte(stdout), 0o600))
+
+	status, stdout, _ := runArgs("diff", old)
+	Equal(t, 1, status)
+	Contains(t, stdout, `BREAKING status Already exists! (resource.already_exists): "400" -> "409"`)
+	Contains(t, stdout, "added Boat sunk! (boat.sunk)")
+	Contains(t, stdout, "8 changes, 7 breaking")
+}
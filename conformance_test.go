@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// goldenFile returns the golden file of a problem named by its stable ID,
+// e.g. testdata/problems/listing.not_found.json
+func goldenFile(e Entry) string {
+	return filepath.Join("testdata", "problems", e.ID+".json")
+}
+
+// TestConformance serves every problem of the DefaultCatalog and compares the
+// response with its golden file. Run `go test -run TestConformance -update`
+// after an intended change of a problem.
+func TestConformance(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger))
+	files := make(map[string]bool)
+
+	for _, e := range DefaultCatalog.Entries() {
+		e := e
+		files[goldenFile(e)] = true
+		t.Run(e.Title, func(t *testing.T) {
+			if !NotEmpty(t, e.ID, "problems need a stable ID") {
+				return
+			}
+			rr := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/conformance", nil)
+			s.ServeError(rr, r, NewProblem(e.Title))
+
+			EqualValues(t, e.Status, rr.Code)
+
+			problem := &models.ProblemDetails{}
+			NoError(t, problem.UnmarshalBinary(rr.Body.Bytes()))
+			NoError(t, problem.Validate(strfmt.Default))
+
+			var got bytes.Buffer
+			NoError(t, json.Indent(&got, rr.Body.Bytes(), "", "  "))
+			got.WriteByte('\n')
+
+			golden := goldenFile(e)
+			if *update {
+				NoError(t, ioutil.WriteFile(golden, got.Bytes(), 0644))
+				return
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if !NoError(t, err, "run go test -run TestConformance -update to create the golden file") {
+				return
+			}
+			Equal(t, string(want), got.String())
+		})
+	}
+
+	golden, err := filepath.Glob(filepath.Join("testdata", "problems", "*.json"))
+	NoError(t, err)
+	for _, file := range golden {
+		True(t, files[file], "%s doesn't belong to any registered problem", file)
+	}
+}
+
+func Test_goldenFile(t *testing.T) {
+	e, _ := DefaultCatalog.Lookup(ListingNotFound)
+	Equal(t, filepath.Join("testdata", "problems", "listing.not_found.json"), goldenFile(e))
+}
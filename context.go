@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// StatusClientClosedRequest is the non-standard status of requests
+// cancelled by the client
+const StatusClientClosedRequest = 499
+
+// contextTitle returns the title of the problem reporting a cancelled
+// request or a timeout anywhere in err's chain
+func contextTitle(err error) (string, bool) {
+	if errors.Is(err, context.Canceled) {
+		return ClientClosedRequest, true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return GatewayTimeout, true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return GatewayTimeout, true
+	}
+	return "", false
+}
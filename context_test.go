@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	. "github.com/stretchr/testify/assert"
+)
+
+func Test_contextTitle(t *testing.T) {
+	title, ok := contextTitle(fmt.Errorf("query: %w", context.DeadlineExceeded))
+	True(t, ok)
+	Equal(t, GatewayTimeout, title)
+
+	title, ok = contextTitle(fmt.Errorf("query: %w", context.Canceled))
+	True(t, ok)
+	Equal(t, ClientClosedRequest, title)
+
+	title, ok = contextTitle(&net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}})
+	True(t, ok)
+	Equal(t, GatewayTimeout, title)
+
+	_, ok = contextTitle(&net.DNSError{})
+	False(t, ok)
+}
+
+func TestServeError_context(t *testing.T) {
+	logger, logs := test.NewNullLogger()
+	logger.SetLevel(log.DebugLevel)
+	s := NewServer(WithLogger(NewLogrusLogger(logger)))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, r, fmt.Errorf("get listings: %w", context.DeadlineExceeded))
+	EqualValues(t, http.StatusGatewayTimeout, rr.Code)
+	Contains(t, rr.Body.String(), GatewayTimeout)
+	Len(t, logs.AllEntries(), 1)
+
+	logs.Reset()
+	rr = httptest.NewRecorder()
+	s.ServeError(rr, r, fmt.Errorf("get listings: %w", context.Canceled))
+	EqualValues(t, StatusClientClosedRequest, rr.Code)
+	Contains(t, rr.Body.String(), ClientClosedRequest)
+	Empty(t, logs.AllEntries())
+}
@@ -0,0 +1,83 @@
+// Package dberrors translates database/sql and driver errors into problems
+// of the errors package tagged with the InstDB instance.
+package dberrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	kerrors "github.com/Kviky/errors"
+)
+
+// sqlStater is implemented by driver errors exposing the SQLSTATE code,
+// e.g. *pgconn.PgError of pgx and *pq.Error of lib/pq
+type sqlStater interface {
+	SQLState() string
+}
+
+// states maps SQLSTATE codes to catalog titles
+var states = map[string]string{
+	"23505": kerrors.AlreadyExists,
+	"23503": kerrors.ReferenceConflict,
+	"40001": kerrors.TransactionConflict,
+	"40P01": kerrors.TransactionConflict,
+	"57P01": kerrors.ServiceUnavailable,
+}
+
+// classes maps SQLSTATE classes to catalog titles
+var classes = map[string]string{
+	// connection exception
+	"08": kerrors.ServiceUnavailable,
+}
+
+// Translate converts a database error into a Problem error with the original
+// error as its cause. Errors which cannot be translated are returned unchanged.
+//
+//	sql.ErrNoRows                  ResourceNotFound
+//	sql.ErrConnDone, ErrBadConn    ServiceUnavailable
+//	23505 unique violation         AlreadyExists
+//	23503 foreign key violation    ReferenceConflict
+//	40001 serialization failure    TransactionConflict (retryable)
+//	40P01 deadlock detected        TransactionConflict (retryable)
+//	57P01, class 08 connection     ServiceUnavailable
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	title, ok := titleOf(err)
+	if !ok {
+		return err
+	}
+
+	p := kerrors.Wrap(err, title)
+	p.Instance = kerrors.InstDB
+	return p
+}
+
+func titleOf(err error) (string, bool) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return kerrors.ResourceNotFound, true
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return kerrors.ServiceUnavailable, true
+	}
+
+	var stater sqlStater
+	if !errors.As(err, &stater) {
+		return "", false
+	}
+
+	state := stater.SQLState()
+	if title, ok := states[state]; ok {
+		return title, true
+	}
+	for class, title := range classes {
+		if strings.HasPrefix(state, class) {
+			return title, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,63 @@
+package dberrors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	kerrors "github.com/Kviky/errors"
+)
+
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "pg error " + e.code
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+func TestTranslate(t *testing.T) {
+	NoError(t, Translate(nil))
+
+	plain := errors.New("boom")
+	Equal(t, plain, Translate(plain))
+
+	unknown := &pgError{code: "22001"}
+	Equal(t, unknown, Translate(unknown))
+
+	tests := []struct {
+		err   error
+		title string
+	}{
+		{sql.ErrNoRows, kerrors.ResourceNotFound},
+		{fmt.Errorf("get listing: %w", sql.ErrNoRows), kerrors.ResourceNotFound},
+		{sql.ErrConnDone, kerrors.ServiceUnavailable},
+		{driver.ErrBadConn, kerrors.ServiceUnavailable},
+		{&pgError{code: "23505"}, kerrors.AlreadyExists},
+		{&pgError{code: "23503"}, kerrors.ReferenceConflict},
+		{&pgError{code: "40001"}, kerrors.TransactionConflict},
+		{&pgError{code: "40P01"}, kerrors.TransactionConflict},
+		{&pgError{code: "08006"}, kerrors.ServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		err := Translate(tt.err)
+		True(t, kerrors.Is(err, tt.title), tt.err.Error())
+		True(t, errors.Is(err, tt.err), tt.err.Error())
+
+		var p *kerrors.Problem
+		True(t, errors.As(err, &p))
+		Equal(t, kerrors.InstDB, p.Instance)
+	}
+
+	True(t, kerrors.IsRetryable(Translate(&pgError{code: "40001"})))
+	False(t, kerrors.IsRetryable(Translate(&pgError{code: "23505"})))
+}
@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Kinds of catalog changes
+const (
+	ChangeRemoved  = "removed"
+	ChangeAdded    = "added"
+	ChangeStatus   = "status"
+	ChangeID       = "id"
+	ChangeName     = "name"
+	ChangeTitle    = "title"
+	ChangeDetail   = "detail"
+	ChangeCode     = "code"
+	ChangeInstance = "instance"
+)
+
+// Change is a difference of a problem between two catalogs
+type Change struct {
+	Kind string `json:"kind"`
+	// Breaking reports that clients of the old catalog may break
+	Breaking bool   `json:"breaking"`
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+}
+
+// String formats the change for release notes
+func (c Change) String() string {
+	s := c.Kind + " " + c.Title
+	if c.ID != "" {
+		s += " (" + c.ID + ")"
+	}
+	if c.Old != "" || c.New != "" {
+		s += fmt.Sprintf(": %q -> %q", c.Old, c.New)
+	}
+	if c.Breaking {
+		s = "BREAKING " + s
+	}
+	return s
+}
+
+// Diff compares two versions of catalog entries. Problems are matched by ID, or by
+// title and then name when they have no ID. Removed problems and changed statuses,
+// existing IDs and names are breaking, reworded details, added IDs, other changes and
+// new problems are not.
+// Reworded titles are breaking only for problems without ID in the old version, as
+// clients branch on IDs once they are served and titles can be localized anyway.
+func Diff(from, to []Entry) []Change {
+	byID := make(map[string]int, len(to))
+	byTitle := make(map[string]int, len(to))
+	byName := make(map[string]int, len(to))
+	for i, e := range to {
+		if e.ID != "" {
+			byID[e.ID] = i
+		}
+		if e.Name != "" {
+			byName[e.Name] = i
+		}
+		byTitle[e.Title] = i
+	}
+
+	var changes []Change
+	matched := make(map[int]bool, len(to))
+	for _, o := range from {
+		i, ok := byID[o.ID]
+		if o.ID == "" || !ok {
+			i, ok = byTitle[o.Title]
+		}
+		if !ok && o.ID == "" && o.Name != "" {
+			i, ok = byName[o.Name]
+		}
+		if !ok || matched[i] {
+			changes = append(changes, Change{Kind: ChangeRemoved, Breaking: true, ID: o.ID, Title: o.Title})
+			continue
+		}
+		matched[i] = true
+		changes = append(changes, diffEntry(o, to[i])...)
+	}
+
+	for i, n := range to {
+		if !matched[i] {
+			changes = append(changes, Change{Kind: ChangeAdded, ID: n.ID, Title: n.Title})
+		}
+	}
+	return changes
+}
+
+// diffEntry compares two versions of a problem
+func diffEntry(o, n Entry) []Change {
+	var changes []Change
+	add := func(kind string, breaking bool, before, after string) {
+		if before != after {
+			changes = append(changes, Change{Kind: kind, Breaking: breaking, ID: n.ID, Title: n.Title, Old: before, New: after})
+		}
+	}
+
+	// clients can't match on an ID the problem didn't have
+	add(ChangeID, o.ID != "", o.ID, n.ID)
+	add(ChangeStatus, true, strconv.Itoa(int(o.Status)), strconv.Itoa(int(n.Status)))
+	add(ChangeName, true, o.Name, n.Name)
+	// clients of problems without ID can only match on the title
+	add(ChangeTitle, o.ID == "", o.Title, n.Title)
+	add(ChangeDetail, false, o.Detail, n.Detail)
+	if o.Status == n.Status {
+		add(ChangeCode, false, o.Code, n.Code)
+	}
+	add(ChangeInstance, false, o.Instance, n.Instance)
+	return changes
+}
+
+// HasBreaking reports whether any of the changes is breaking
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
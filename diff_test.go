@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := []Entry{
+		{Name: "ListingNotFound", ID: "listing.not_found", Title: "Listing not found!", Detail: "Missing", Status: 404, Code: notFound},
+		{Name: "OffersEnded", ID: "offers.ended", Title: "Offers ended today!", Status: 400, Code: badRequest},
+		{Name: "AlreadyExists", ID: "resource.exists", Title: AlreadyExists, Status: 400, Code: badRequest},
+		{Title: "Legacy!", Status: 400, Code: badRequest},
+		{ID: "boat.sunk", Title: "Boat sunk!", Status: 410, Code: gone},
+		{Name: "PortClosed", Title: "Port closed!", Status: 400, Code: badRequest},
+		{Title: "Boat booked!", Status: 409, Code: conflict},
+	}
+	current := []Entry{
+		{Name: "ListingNotFound", ID: "listing.not_found", Title: "Listing was not found!", Detail: "Gone", Status: 404, Code: notFound},
+		{Name: "OffersEnded", ID: "offers.ended", Title: "Offers ended today!", Status: 429, Code: tooManyRequests},
+		{Name: "AlreadyExists", ID: "resource.already_exists", Title: AlreadyExists, Status: 400, Code: badRequest},
+		{Title: "Legacy!", Status: 400, Code: badRequest, Instance: InstApi},
+		{ID: "boat.missing", Title: "Boat missing!", Status: 404, Code: notFound},
+		{Name: "PortClosed", Title: "Port is closed!", Status: 400, Code: badRequest},
+		{ID: "boat.booked", Title: "Boat booked!", Status: 409, Code: conflict},
+	}
+
+	var got []string
+	for _, c := range Diff(old, current) {
+		got = append(got, c.String())
+	}
+	Equal(t, []string{
+		`title Listing was not found! (listing.not_found): "Listing not found!" -> "Listing was not found!"`,
+		`detail Listing was not found! (listing.not_found): "Missing" -> "Gone"`,
+		`BREAKING status Offers ended today! (offers.ended): "400" -> "429"`,
+		`BREAKING id Already exists! (resource.already_exists): "resource.exists" -> "resource.already_exists"`,
+		`instance Legacy!: "" -> "api"`,
+		`BREAKING removed Boat sunk! (boat.sunk)`,
+		`BREAKING title Port is closed!: "Port closed!" -> "Port is closed!"`,
+		`id Boat booked! (boat.booked): "" -> "boat.booked"`,
+		`added Boat missing! (boat.missing)`,
+	}, got)
+	True(t, HasBreaking(Diff(old, current)))
+}
+
+func TestDiff_defaultEntries(t *testing.T) {
+	Empty(t, Diff(DefaultEntries(), DefaultEntries()))
+
+	strict := NewCatalog(DefaultEntries()...)
+	strict.UseStrictStatuses()
+	changes := Diff(DefaultEntries(), strict.Entries())
+	Len(t, changes, len(strictStatuses))
+	True(t, HasBreaking(changes))
+	False(t, HasBreaking(nil))
+}
@@ -0,0 +1,260 @@
+package errors
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	cer "github.com/go-openapi/errors"
+)
+
+// Guide documents why a problem happens and how clients resolve it
+type Guide struct {
+	// Causes are the likely causes of the problem
+	Causes []string `json:"causes,omitempty" yaml:"causes,omitempty"`
+	// Remediation tells clients how to resolve the problem
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// statusGuides are the default guides of problems by status
+var statusGuides = map[int32]Guide{
+	http.StatusBadRequest: {
+		Causes:      []string{"A parameter of the request is missing or malformed.", "The request body doesn't match the API definition."},
+		Remediation: "Fix the request according to the detail and invalidParams of the problem and send it again.",
+	},
+	http.StatusUnauthorized: {
+		Causes:      []string{"The authorization token is missing, expired or invalid."},
+		Remediation: "Obtain a new token and send the request again.",
+	},
+	http.StatusForbidden: {
+		Causes:      []string{"The account has no permission for the action or the resource."},
+		Remediation: "Use an account with sufficient permissions or ask an administrator for access.",
+	},
+	http.StatusNotFound: {
+		Causes:      []string{"The identifier in the request is wrong.", "The resource was deleted."},
+		Remediation: "Check the identifier in the request.",
+	},
+	http.StatusMethodNotAllowed: {
+		Causes:      []string{"The endpoint doesn't support the HTTP method."},
+		Remediation: "Use one of the methods listed in the Allow header.",
+	},
+	http.StatusConflict: {
+		Causes:      []string{"The request conflicts with the current state of the resource."},
+		Remediation: "Reload the resource, resolve the conflict and send the request again.",
+	},
+	http.StatusGone: {
+		Causes:      []string{"The resource was deleted permanently."},
+		Remediation: "Remove all references to the resource.",
+	},
+	http.StatusPreconditionFailed: {
+		Causes:      []string{"The resource was modified since the client read it."},
+		Remediation: "Reload the resource and apply the changes to its current version.",
+	},
+	http.StatusRequestEntityTooLarge: {
+		Causes:      []string{"The request body exceeds the allowed size."},
+		Remediation: "Reduce the size of the request body.",
+	},
+	http.StatusUnsupportedMediaType: {
+		Causes:      []string{"The request body has an unsupported content type."},
+		Remediation: "Send the body in one of the supported content types.",
+	},
+	http.StatusUnprocessableEntity: {
+		Causes:      []string{"The request is well-formed but its values violate business rules."},
+		Remediation: "Fix the values listed in invalidParams of the problem.",
+	},
+	http.StatusPreconditionRequired: {
+		Causes:      []string{"The update has no If-Match header."},
+		Remediation: "Send the ETag of the resource in the If-Match header.",
+	},
+	http.StatusTooManyRequests: {
+		Causes:      []string{"The client sent too many requests in a short time."},
+		Remediation: "Retry later with exponential backoff.",
+	},
+	StatusClientClosedRequest: {
+		Causes:      []string{"The client closed the connection before the response was sent."},
+		Remediation: "No action is needed, the problem is only logged by the service.",
+	},
+	http.StatusInternalServerError: {
+		Causes:      []string{"An unexpected error occurred in the service."},
+		Remediation: "Try again later and contact support when the problem persists.",
+	},
+	http.StatusServiceUnavailable: {
+		Causes:      []string{"The service is overloaded or under maintenance."},
+		Remediation: "Retry later with exponential backoff.",
+	},
+	http.StatusGatewayTimeout: {
+		Causes:      []string{"A service needed to process the request didn't respond in time."},
+		Remediation: "Retry later. Requests which are not idempotent might have been processed.",
+	},
+}
+
+// GuideForStatus returns the default guide of a problem with given status
+func GuideForStatus(status int32) Guide {
+	if g, ok := statusGuides[status]; ok {
+		return g
+	}
+	if status >= http.StatusInternalServerError {
+		return statusGuides[http.StatusInternalServerError]
+	}
+	return statusGuides[http.StatusBadRequest]
+}
+
+// DocsHandler serves human-readable documentation of catalog problems, so type URIs
+// of served problems can dereference to it (see WithTypeBase). The index is served
+// at Prefix and every problem at Prefix/{id}, as HTML or as JSON when the client
+// accepts application/json.
+//
+//	http.Handle("/problems", &errors.DocsHandler{})
+//	http.Handle("/problems/", &errors.DocsHandler{})
+type DocsHandler struct {
+	// Catalog is documented, DefaultCatalog when nil
+	Catalog *Catalog
+	// Prefix is the path the handler is mounted at, /problems when empty
+	Prefix string
+	// Server serves unknown problems and methods, DefaultServer when nil
+	Server *Server
+}
+
+// ProblemDoc is the documentation of a single problem
+type ProblemDoc struct {
+	ID          string      `json:"id"`
+	Href        string      `json:"href"`
+	Title       string      `json:"title"`
+	Status      int32       `json:"status"`
+	Code        string      `json:"code"`
+	Instance    string      `json:"instance"`
+	Description string      `json:"description"`
+	Causes      []string    `json:"causes,omitempty"`
+	Remediation string      `json:"remediation,omitempty"`
+	Retry       Retry       `json:"retry"`
+	Example     interface{} `json:"example"`
+}
+
+// ServeHTTP implements http.Handler
+func (h *DocsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		h.server().ServeError(rw, r, cer.MethodNotAllowed(r.Method, []string{http.MethodGet, http.MethodHead}))
+		return
+	}
+
+	catalog := h.Catalog
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+	prefix := strings.TrimSuffix(h.Prefix, "/")
+	if prefix == "" {
+		prefix = "/problems"
+	}
+
+	entries := catalog.Entries()
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if id == "" {
+		docs := make([]ProblemDoc, 0, len(entries))
+		for _, e := range entries {
+			docs = append(docs, catalog.doc(e, prefix))
+		}
+		h.write(rw, r, docsIndex, docs)
+		return
+	}
+	for _, e := range entries {
+		if docID(e) == id {
+			h.write(rw, r, docsProblem, catalog.doc(e, prefix))
+			return
+		}
+	}
+	h.server().ServeError(rw, r, NewProblem(ResourceNotFound))
+}
+
+func (h *DocsHandler) server() *Server {
+	if h.Server == nil {
+		return DefaultServer
+	}
+	return h.Server
+}
+
+// docID returns the path segment of the documentation of an entry
+func docID(e Entry) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return specName(e)
+}
+
+// doc creates the documentation of an entry
+func (c *Catalog) doc(e Entry, prefix string) ProblemDoc {
+	id := docID(e)
+	guide := e.Documentation()
+	return ProblemDoc{
+		ID:          id,
+		Href:        prefix + "/" + id,
+		Title:       e.Title,
+		Status:      e.Status,
+		Code:        e.Code,
+		Instance:    e.Instance,
+		Description: e.Detail,
+		Causes:      guide.Causes,
+		Remediation: guide.Remediation,
+		Retry:       e.RetryPolicy(),
+		Example:     c.example(e.Title),
+	}
+}
+
+// write writes data as JSON when the client accepts it, otherwise as HTML page
+func (h *DocsHandler) write(rw http.ResponseWriter, r *http.Request, page *template.Template, data interface{}) {
+	if acceptsJSON(r.Header.Get("Accept")) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(rw).Encode(data)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.WriteHeader(http.StatusOK)
+	_ = page.Execute(rw, data)
+}
+
+// acceptsJSON reports whether the Accept header lists a JSON media type
+func acceptsJSON(accept string) bool {
+	for _, mediaType := range strings.Split(accept, ",") {
+		if isJSON(strings.TrimSpace(mediaType)) {
+			return true
+		}
+	}
+	return false
+}
+
+var docsFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+}
+
+const docsStyle = `<style>body{font-family:sans-serif;max-width:50em;margin:2em auto}td,th{padding:.2em 1em;text-align:left}pre{background:#f4f4f4;padding:1em}</style>`
+
+var docsIndex = template.Must(template.New("index").Funcs(docsFuncs).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Problems</title>` + docsStyle + `</head>
+<body>
+<h1>Problems</h1>
+<table>
+<tr><th>Status</th><th>ID</th><th>Title</th></tr>
+{{range .}}<tr><td>{{.Status}}</td><td><a href="{{.Href}}">{{.ID}}</a></td><td>{{.Title}}</td></tr>
+{{end}}</table>
+</body></html>
+`))
+
+var docsProblem = template.Must(template.New("problem").Funcs(docsFuncs).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Title}}</title>` + docsStyle + `</head>
+<body>
+<h1>{{.Title}}</h1>
+<p><code>{{.ID}}</code> &middot; {{.Status}} {{.Code}} &middot; {{.Instance}}</p>
+<p>{{.Description}}</p>
+{{if .Causes}}<h2>Likely causes</h2>
+<ul>{{range .Causes}}<li>{{.}}</li>{{end}}</ul>
+{{end}}{{if .Remediation}}<h2>Remediation</h2>
+<p>{{.Remediation}}</p>
+{{end}}{{if .Retry.Retryable}}<p>The request may be retried{{if .Retry.IdempotentOnly}} if it is idempotent{{end}}.</p>
+{{end}}<h2>Example</h2>
+<pre>{{json .Example}}</pre>
+</body></html>
+`))
@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func serveDocs(h http.Handler, method, path, accept string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(method, path, nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	h.ServeHTTP(rr, r)
+	return rr
+}
+
+func TestGuideForStatus(t *testing.T) {
+	Equal(t, statusGuides[http.StatusNotFound], GuideForStatus(http.StatusNotFound))
+	Equal(t, statusGuides[http.StatusBadRequest], GuideForStatus(418))
+	Equal(t, statusGuides[http.StatusInternalServerError], GuideForStatus(502))
+
+	for _, e := range DefaultCatalog.Entries() {
+		guide := e.Documentation()
+		NotEmpty(t, guide.Causes, e.Title)
+		NotEmpty(t, guide.Remediation, e.Title)
+	}
+}
+
+func TestDocsHandler_index(t *testing.T) {
+	h := &DocsHandler{}
+
+	rr := serveDocs(h, http.MethodGet, "/problems", "")
+	EqualValues(t, http.StatusOK, rr.Code)
+	Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+	Contains(t, rr.Body.String(), `<a href="/problems/listing.not_found">listing.not_found</a>`)
+
+	rr = serveDocs(h, http.MethodGet, "/problems/", "application/json")
+	EqualValues(t, http.StatusOK, rr.Code)
+	var docs []ProblemDoc
+	NoError(t, json.Unmarshal(rr.Body.Bytes(), &docs))
+	Len(t, docs, len(DefaultCatalog.Entries()))
+}
+
+func TestDocsHandler_problem(t *testing.T) {
+	h := &DocsHandler{Prefix: "/docs/problems/"}
+
+	rr := serveDocs(h, http.MethodGet, "/docs/problems/reservation.double_booking", "text/html, application/json;q=0.9")
+	EqualValues(t, http.StatusOK, rr.Code)
+	var doc ProblemDoc
+	NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	Equal(t, DoubleBooking, doc.Title)
+	EqualValues(t, http.StatusConflict, doc.Status)
+	Equal(t, "/docs/problems/reservation.double_booking", doc.Href)
+	Equal(t, []string{"Another reservation of the boat overlaps the requested dates."}, doc.Causes)
+	Equal(t, "reservation.double_booking", lookup(t, doc.Example, "id"))
+
+	rr = serveDocs(h, http.MethodGet, "/docs/problems/gateway.timeout", "")
+	Contains(t, rr.Body.String(), "<h1>Gateway Timeout!</h1>")
+	Contains(t, rr.Body.String(), "The request may be retried if it is idempotent.")
+	Contains(t, rr.Body.String(), "&#34;id&#34;: &#34;gateway.timeout&#34;")
+}
+
+func TestDocsHandler_errors(t *testing.T) {
+	h := &DocsHandler{Catalog: NewCatalog(Entry{Title: "Boat sunk!", Status: 410})}
+
+	rr := serveDocs(h, http.MethodGet, "/problems/BoatSunk", "")
+	EqualValues(t, http.StatusOK, rr.Code)
+
+	rr = serveDocs(h, http.MethodGet, "/problems/listing.not_found", "")
+	EqualValues(t, http.StatusNotFound, rr.Code)
+
+	rr = serveDocs(h, http.MethodPost, "/problems", "")
+	EqualValues(t, http.StatusMethodNotAllowed, rr.Code)
+	Equal(t, "GET,HEAD", rr.Header().Get("Allow"))
+}
+
+func TestDocsHandler_server(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger), WithContentType("application/problem+json"), WithTypeBase("/problems/"))
+	h := &DocsHandler{Server: s}
+
+	rr := serveDocs(h, http.MethodGet, "/problems/boat.sunk", "")
+	EqualValues(t, http.StatusNotFound, rr.Code)
+	Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	Contains(t, rr.Body.String(), `"type":"/problems/resource.not_found"`)
+
+	rr = serveDocs(h, http.MethodDelete, "/problems", "")
+	EqualValues(t, http.StatusMethodNotAllowed, rr.Code)
+	Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+}
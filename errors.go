@@ -2,22 +2,18 @@ package errors
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
-
-	log "github.com/sirupsen/logrus"
+	"time"
 
 	"github.com/Kviky/errors/models"
 
 	"github.com/go-openapi/errors"
 )
 
-const (
-	supportText = " Please try again later or contact support at info@kviky.com!"
-)
-
 // Types of instances
 const (
 	InstApp      = "app"
@@ -31,22 +27,27 @@ const (
 
 // List of error codes
 const (
-	unauthorized        = "Unauthorized"
-	temporaryRedirect   = "Temporary Redirect"
-	permanentRedirect   = "Permanent Redirect"
-	badRequest          = "Bad Request"
-	notImplemented      = "Not Implemented"
-	forbidden           = "Forbidden"
-	notFound            = "Not Found"
-	gone                = "Gone"
-	preconditionFailed  = "Precondition Failed"
-	unprocessableEntry  = "Unprocessable Entity"
-	lengthRequired      = "Length Required"
-	tooManyRequests     = "Too Many Requests"
-	internalServerError = "Internal Server Error"
-	serviceUnavailable  = "Service Unavailable"
-	methodNotAllowed    = "Method Not Allowed"
-	gatewayTimeout      = "Gateway Timeout"
+	unauthorized          = "Unauthorized"
+	temporaryRedirect     = "Temporary Redirect"
+	permanentRedirect     = "Permanent Redirect"
+	badRequest            = "Bad Request"
+	notImplemented        = "Not Implemented"
+	forbidden             = "Forbidden"
+	notFound              = "Not Found"
+	gone                  = "Gone"
+	preconditionFailed    = "Precondition Failed"
+	preconditionRequired  = "Precondition Required"
+	unprocessableEntry    = "Unprocessable Entity"
+	lengthRequired        = "Length Required"
+	tooManyRequests       = "Too Many Requests"
+	internalServerError   = "Internal Server Error"
+	serviceUnavailable    = "Service Unavailable"
+	methodNotAllowed      = "Method Not Allowed"
+	conflict              = "Conflict"
+	requestEntityTooLarge = "Request Entity Too Large"
+	unsupportedMediaType  = "Unsupported Media Type"
+	gatewayTimeout        = "Gateway Timeout"
+	clientClosedRequest   = "Client Closed Request"
 )
 
 // List of 400 errors
@@ -109,11 +110,57 @@ const (
 	MethodNotAllowed = "Method not allowed!"
 )
 
+// list of 409 errors
+const (
+	DoubleBooking       = "Double booking!"
+	ReferenceConflict   = "Reference conflict!"
+	ResourceConflict    = "Resource conflict!"
+	TransactionConflict = "Transaction conflict!"
+)
+
+// list of 410 errors
+const (
+	ListingDeleted = "Listing deleted!"
+	ResourceGone   = "Resource gone!"
+)
+
+// list of 412 errors
+const (
+	PreconditionFailed = "Precondition failed!"
+)
+
+// list of 413 errors
+const (
+	ImageTooLarge   = "Image too large!"
+	PayloadTooLarge = "Payload too large!"
+)
+
+// list of 415 errors
+const (
+	UnsupportedImageType = "Unsupported image type!"
+	UnsupportedMediaType = "Unsupported media type!"
+)
+
+// list of 422 errors
+const (
+	ValidationFailed = "Validation failed!"
+)
+
+// list of 428 errors
+const (
+	PreconditionRequired = "Precondition required!"
+)
+
 // list of 429 errors
 const (
 	CongestionRisk = "Too many requests!"
 )
 
+// list of 499 errors
+const (
+	ClientClosedRequest = "Client closed request!"
+)
+
 // List of 500 errors
 const (
 	SystemFailure      = "System failure!"
@@ -130,262 +177,609 @@ const (
 	GatewayTimeout = "Gateway Timeout!"
 )
 
-// CreateProblemDetails - Helper function to create ProblemDetails object
-func CreateProblemDetails(errorName string) *models.ProblemDetails {
-	problem := &models.ProblemDetails{}
-	problem.Title = errorName
-	problem.Type = "/"
-
-	switch errorName {
-
+// defaultEntries - Problems registered in the DefaultCatalog
+var defaultEntries = []Entry{
 	// 400 ERRORS
-	case AlreadyExists:
-		problem.Detail = "The requested resource already exists!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case BadRequest:
-		problem.Detail = "There was a problem with the request!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case CharterHasListings:
-		problem.Detail = "Charter cannot be deleted, because it still has some active listings!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case CharterNotCreated:
-		problem.Detail = "There was a problem to create charter profile!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case FileExistsAlready:
-		problem.Detail = "File with same name exists already! Please, specify another name."
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case FileNotCreated:
-		problem.Detail = "There was a problem to create file!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstExport
-	case InvalidBodyParam:
-		problem.Detail = "The HTTP request contains an unsupported body parameter!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidDates:
-		problem.Detail = "The requested dates are invalid!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidHeaderParam:
-		problem.Detail = "The HTTP request contains an unsupported header parameter!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidMsgFormat:
-		problem.Detail = "The HTTP request has an invalid format!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case ImageInvalid:
-		problem.Detail = "File must be a valid image - image/jpeg, image/jpg, image/png!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case ImageNotDeleted:
-		problem.Detail = "There was a problem to delete image!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstImage
-	case ImageNotUploaded:
-		problem.Detail = "There was a problem to upload image!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstImage
-	case InactiveListing:
-		// listingid should be added manually
-		problem.Detail = "Listing %v is not in the active state!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidOwnerListing:
-		// listingid should be added manually
-		problem.Detail = "Charter doesn't own the listing %v!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidQueryParam:
-		problem.Detail = "The HTTP request contains an unsupported query parameter in the URI!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case InvalidPathParam:
-		problem.Detail = "The HTTP request contains an unsupported path parameter in the URI!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case ListingNotCreated:
-		problem.Detail = "There was a problem to create listing!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case LocationNotCreated:
-		problem.Detail = "There was a problem to create location!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case MandatoryParamIncorrect:
-		problem.Detail = "Mandatory parameter has semantically incorrect value!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case MandatoryParamMissing:
-		problem.Detail = "Parameter which is defined as mandatory is missing!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case NameAlreadyTaken:
-		problem.Detail = "Requested name is already taken! Please, specify another name."
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case OffersEnded:
-		problem.Detail = "Available number of the offers ended for today!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case OffersMaxListings:
-		problem.Detail = "Maximum limit of %v listings is reached. Please, reduce number of listings in offer!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case PortAlreadyExists:
-		problem.Detail = "Requested port/marina name already exists for this country and city!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
-	case ReservationNotCreated:
-		problem.Detail = "There was a problem to create reservation!"
-		problem.Status = 400
-		problem.Code = badRequest
-		problem.Instance = InstClient
+	{
+		Name:     "AlreadyExists",
+		ID:       "resource.already_exists",
+		Title:    AlreadyExists,
+		Detail:   "The requested resource already exists!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "BadRequest",
+		ID:       "request.bad",
+		Title:    BadRequest,
+		Detail:   "There was a problem with the request!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "CharterHasListings",
+		ID:       "charter.has_listings",
+		Title:    CharterHasListings,
+		Detail:   "Charter cannot be deleted, because it still has some active listings!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "CharterNotCreated",
+		ID:       "charter.not_created",
+		Title:    CharterNotCreated,
+		Detail:   "There was a problem to create charter profile!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "FileExistsAlready",
+		ID:       "file.already_exists",
+		Title:    FileExistsAlready,
+		Detail:   "File with same name exists already! Please, specify another name.",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "FileNotCreated",
+		ID:       "file.not_created",
+		Title:    FileNotCreated,
+		Detail:   "There was a problem to create file!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstExport,
+	},
+	{
+		Name:     "InvalidBodyParam",
+		ID:       "param.invalid_body",
+		Title:    InvalidBodyParam,
+		Detail:   "The HTTP request contains an unsupported body parameter!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "InvalidDates",
+		ID:       "dates.invalid",
+		Title:    InvalidDates,
+		Detail:   "The requested dates are invalid!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "InvalidHeaderParam",
+		ID:       "param.invalid_header",
+		Title:    InvalidHeaderParam,
+		Detail:   "The HTTP request contains an unsupported header parameter!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "InvalidMsgFormat",
+		ID:       "message.invalid_format",
+		Title:    InvalidMsgFormat,
+		Detail:   "The HTTP request has an invalid format!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ImageInvalid",
+		ID:       "image.invalid",
+		Title:    ImageInvalid,
+		Detail:   "File must be a valid image - image/jpeg, image/jpg, image/png!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ImageNotDeleted",
+		ID:       "image.not_deleted",
+		Title:    ImageNotDeleted,
+		Detail:   "There was a problem to delete image!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstImage,
+	},
+	{
+		Name:     "ImageNotUploaded",
+		ID:       "image.not_uploaded",
+		Title:    ImageNotUploaded,
+		Detail:   "There was a problem to upload image!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstImage,
+	},
+	// listingid should be added manually
+	{
+		Name:     "InactiveListing",
+		ID:       "listing.inactive",
+		Title:    InactiveListing,
+		Detail:   "Listing %v is not in the active state!",
+		Template: true,
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	// listingid should be added manually
+	{
+		Name:     "InvalidOwnerListing",
+		ID:       "listing.invalid_owner",
+		Title:    InvalidOwnerListing,
+		Detail:   "Charter doesn't own the listing %v!",
+		Template: true,
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "InvalidQueryParam",
+		ID:       "param.invalid_query",
+		Title:    InvalidQueryParam,
+		Detail:   "The HTTP request contains an unsupported query parameter in the URI!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "InvalidPathParam",
+		ID:       "param.invalid_path",
+		Title:    InvalidPathParam,
+		Detail:   "The HTTP request contains an unsupported path parameter in the URI!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ListingNotCreated",
+		ID:       "listing.not_created",
+		Title:    ListingNotCreated,
+		Detail:   "There was a problem to create listing!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "LocationNotCreated",
+		ID:       "location.not_created",
+		Title:    LocationNotCreated,
+		Detail:   "There was a problem to create location!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "MandatoryParamIncorrect",
+		ID:       "param.mandatory_incorrect",
+		Title:    MandatoryParamIncorrect,
+		Detail:   "Mandatory parameter has semantically incorrect value!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "MandatoryParamMissing",
+		ID:       "param.mandatory_missing",
+		Title:    MandatoryParamMissing,
+		Detail:   "Parameter which is defined as mandatory is missing!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "NameAlreadyTaken",
+		ID:       "name.already_taken",
+		Title:    NameAlreadyTaken,
+		Detail:   "Requested name is already taken! Please, specify another name.",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "OffersEnded",
+		ID:       "offers.ended",
+		Title:    OffersEnded,
+		Detail:   "Available number of the offers ended for today!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The daily number of offers of the account is used up."},
+			Remediation: "Send the offer again tomorrow.",
+		},
+	},
+	{
+		Name:     "OffersMaxListings",
+		ID:       "offers.max_listings",
+		Title:    OffersMaxListings,
+		Detail:   "Maximum limit of %v listings is reached. Please, reduce number of listings in offer!",
+		Template: true,
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The offer has more listings than allowed."},
+			Remediation: "Reduce the number of listings in the offer.",
+		},
+	},
+	{
+		Name:     "PortAlreadyExists",
+		ID:       "port.already_exists",
+		Title:    PortAlreadyExists,
+		Detail:   "Requested port/marina name already exists for this country and city!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ReservationNotCreated",
+		ID:       "reservation.not_created",
+		Title:    ReservationNotCreated,
+		Detail:   "There was a problem to create reservation!",
+		Status:   400,
+		Code:     badRequest,
+		Instance: InstClient,
+	},
 
 	// 401 ERRORS
-	case InvalidAuthToken:
-		problem.Detail = "Authorization token is invalid!"
-		problem.Status = 401
-		problem.Code = unauthorized
-		problem.Instance = InstClient
-	case MissingAuthToken:
-		problem.Detail = "Authorization token is missing!"
-		problem.Status = 401
-		problem.Code = unauthorized
-		problem.Instance = InstClient
-	case UnauthorizedAccess:
-		problem.Detail = "The request doesn't have permissions to access resources!"
-		problem.Status = 401
-		problem.Code = unauthorized
-		problem.Instance = InstApi
+	{
+		Name:     "InvalidAuthToken",
+		ID:       "auth.invalid_token",
+		Title:    InvalidAuthToken,
+		Detail:   "Authorization token is invalid!",
+		Status:   401,
+		Code:     unauthorized,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The token is expired.", "The token was issued for another service or is malformed."},
+			Remediation: "Refresh the token and send the request again.",
+		},
+	},
+	{
+		Name:     "MissingAuthToken",
+		ID:       "auth.missing_token",
+		Title:    MissingAuthToken,
+		Detail:   "Authorization token is missing!",
+		Status:   401,
+		Code:     unauthorized,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The request has no Authorization header."},
+			Remediation: "Send the token in the Authorization header.",
+		},
+	},
+	{
+		Name:     "UnauthorizedAccess",
+		ID:       "auth.unauthorized",
+		Title:    UnauthorizedAccess,
+		Detail:   "The request doesn't have permissions to access resources!",
+		Status:   401,
+		Code:     unauthorized,
+		Instance: InstApi,
+	},
 
 	// 403 ERRORS
-	case ForbiddenAction:
-		problem.Detail = "You don't have a permission to make this action!"
-		problem.Status = 403
-		problem.Code = forbidden
-		problem.Instance = InstClient
-	case ForbiddenResource:
-		problem.Detail = "You don't have a permission to access this resource!"
-		problem.Status = 403
-		problem.Code = forbidden
-		problem.Instance = InstClient
-	case ForbiddenUpload:
-		problem.Detail = "This accound doesn't have permission to upload images!"
-		problem.Status = 403
-		problem.Code = forbidden
-		problem.Instance = InstClient
+	{
+		Name:     "ForbiddenAction",
+		ID:       "forbidden.action",
+		Title:    ForbiddenAction,
+		Detail:   "You don't have a permission to make this action!",
+		Status:   403,
+		Code:     forbidden,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ForbiddenResource",
+		ID:       "forbidden.resource",
+		Title:    ForbiddenResource,
+		Detail:   "You don't have a permission to access this resource!",
+		Status:   403,
+		Code:     forbidden,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ForbiddenUpload",
+		ID:       "forbidden.upload",
+		Title:    ForbiddenUpload,
+		Detail:   "This account doesn't have permission to upload images!",
+		Status:   403,
+		Code:     forbidden,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The account is not allowed to upload images, e.g. it is not verified."},
+			Remediation: "Use a verified account or contact support.",
+		},
+	},
 
 	// 404 ERRORS
-	case CharterNotFound:
-		problem.Detail = "The charter indicated in the request does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case ListingNotFound:
-		problem.Detail = "The listing indicated in the request does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case LocationNotFound:
-		problem.Detail = "The location indicated in the request does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case ReservationNotFound:
-		problem.Detail = "Requested reservation does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case ResourceNotFound:
-		problem.Detail = "Requested resource does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case UserNotFound:
-		problem.Detail = "The user indicated in the request does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
-	case UsersNotFound:
-		problem.Detail = "Requested users does not exist!"
-		problem.Status = 404
-		problem.Code = notFound
-		problem.Instance = InstClient
+	{
+		Name:     "CharterNotFound",
+		ID:       "charter.not_found",
+		Title:    CharterNotFound,
+		Detail:   "The charter indicated in the request does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ListingNotFound",
+		ID:       "listing.not_found",
+		Title:    ListingNotFound,
+		Detail:   "The listing indicated in the request does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "LocationNotFound",
+		ID:       "location.not_found",
+		Title:    LocationNotFound,
+		Detail:   "The location indicated in the request does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ReservationNotFound",
+		ID:       "reservation.not_found",
+		Title:    ReservationNotFound,
+		Detail:   "Requested reservation does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ResourceNotFound",
+		ID:       "resource.not_found",
+		Title:    ResourceNotFound,
+		Detail:   "Requested resource does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "UserNotFound",
+		ID:       "user.not_found",
+		Title:    UserNotFound,
+		Detail:   "The user indicated in the request does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
+	{
+		Name:     "UsersNotFound",
+		ID:       "users.not_found",
+		Title:    UsersNotFound,
+		Detail:   "Requested users does not exist!",
+		Status:   404,
+		Code:     notFound,
+		Instance: InstClient,
+	},
 
 	// 405 ERRORS
-	case MethodNotAllowed:
-		problem.Detail = "Requested method is not allowed. Check the response header `Allow` for allowed methods!"
-		problem.Status = 405
-		problem.Code = methodNotAllowed
-		problem.Instance = InstClient
+	{
+		Name:     "MethodNotAllowed",
+		ID:       "request.method_not_allowed",
+		Title:    MethodNotAllowed,
+		Detail:   "Requested method is not allowed. Check the response header `Allow` for allowed methods!",
+		Status:   405,
+		Code:     methodNotAllowed,
+		Instance: InstClient,
+	},
+
+	// 409 ERRORS
+	{
+		Name:     "DoubleBooking",
+		ID:       "reservation.double_booking",
+		Title:    DoubleBooking,
+		Detail:   "The boat is already booked for the requested dates!",
+		Status:   409,
+		Code:     conflict,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"Another reservation of the boat overlaps the requested dates."},
+			Remediation: "Choose other dates or another boat.",
+		},
+	},
+	{
+		Name:     "ReferenceConflict",
+		ID:       "resource.reference_conflict",
+		Title:    ReferenceConflict,
+		Detail:   "The request conflicts with resources referencing or referenced by the resource!",
+		Status:   409,
+		Code:     conflict,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ResourceConflict",
+		ID:       "resource.conflict",
+		Title:    ResourceConflict,
+		Detail:   "The request conflicts with the current state of the resource!",
+		Status:   409,
+		Code:     conflict,
+		Instance: InstClient,
+	},
+	{
+		Name:     "TransactionConflict",
+		ID:       "transaction.conflict",
+		Title:    TransactionConflict,
+		Detail:   "The request conflicts with a concurrent request. Please, try again!",
+		Status:   409,
+		Code:     conflict,
+		Instance: InstApi,
+		Retry:    &Retry{Retryable: true, Backoff: 100 * time.Millisecond},
+	},
+
+	// 410 ERRORS
+	{
+		Name:     "ListingDeleted",
+		ID:       "listing.deleted",
+		Title:    ListingDeleted,
+		Detail:   "The listing indicated in the request was deleted!",
+		Status:   410,
+		Code:     gone,
+		Instance: InstClient,
+	},
+	{
+		Name:     "ResourceGone",
+		ID:       "resource.gone",
+		Title:    ResourceGone,
+		Detail:   "Requested resource was deleted!",
+		Status:   410,
+		Code:     gone,
+		Instance: InstClient,
+	},
+
+	// 412 ERRORS
+	{
+		Name:     "PreconditionFailed",
+		ID:       "precondition.failed",
+		Title:    PreconditionFailed,
+		Detail:   "The resource was modified by another request! Please, reload it and try again.",
+		Status:   412,
+		Code:     preconditionFailed,
+		Instance: InstClient,
+	},
+
+	// 413 ERRORS
+	{
+		Name:     "ImageTooLarge",
+		ID:       "image.too_large",
+		Title:    ImageTooLarge,
+		Detail:   "The uploaded image exceeds the maximum allowed size!",
+		Status:   413,
+		Code:     requestEntityTooLarge,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The size or dimensions of the image exceed the limits listed in invalidParams."},
+			Remediation: "Resize or compress the image and upload it again.",
+		},
+	},
+	{
+		Name:     "PayloadTooLarge",
+		ID:       "request.payload_too_large",
+		Title:    PayloadTooLarge,
+		Detail:   "The HTTP request body exceeds the maximum allowed size!",
+		Status:   413,
+		Code:     requestEntityTooLarge,
+		Instance: InstClient,
+	},
+
+	// 415 ERRORS
+	{
+		Name:     "UnsupportedImageType",
+		ID:       "image.unsupported_type",
+		Title:    UnsupportedImageType,
+		Detail:   "Image must be of type image/jpeg, image/jpg or image/png!",
+		Status:   415,
+		Code:     unsupportedMediaType,
+		Instance: InstClient,
+		Guide: &Guide{
+			Causes:      []string{"The image is not a JPEG or PNG."},
+			Remediation: "Convert the image to JPEG or PNG and upload it again.",
+		},
+	},
+	{
+		Name:     "UnsupportedMediaType",
+		ID:       "request.unsupported_media_type",
+		Title:    UnsupportedMediaType,
+		Detail:   "The HTTP request body has an unsupported content type!",
+		Status:   415,
+		Code:     unsupportedMediaType,
+		Instance: InstClient,
+	},
+
+	// 422 ERRORS
+	{
+		Name:     "ValidationFailed",
+		ID:       "validation.failed",
+		Title:    ValidationFailed,
+		Detail:   "The request is well-formed, but contains semantically incorrect values!",
+		Status:   422,
+		Code:     unprocessableEntry,
+		Instance: InstClient,
+	},
+
+	// 428 ERRORS
+	{
+		Name:     "PreconditionRequired",
+		ID:       "precondition.required",
+		Title:    PreconditionRequired,
+		Detail:   "The request must be conditional! Please, specify the If-Match header.",
+		Status:   428,
+		Code:     preconditionRequired,
+		Instance: InstClient,
+	},
 
 	// 429 ERRORS
-	case CongestionRisk:
-		problem.Detail = "The request is rejected due to excessive traffic. If continued over time, may lead to an overload situation."
-		problem.Status = 429
-		problem.Code = tooManyRequests
-		problem.Instance = InstClient
+	{
+		Name:     "CongestionRisk",
+		ID:       "request.too_many",
+		Title:    CongestionRisk,
+		Detail:   "The request is rejected due to excessive traffic. If continued over time, may lead to an overload situation.",
+		Status:   429,
+		Code:     tooManyRequests,
+		Instance: InstClient,
+	},
+
+	// 499 ERRORS
+	{
+		Name:     "ClientClosedRequest",
+		ID:       "request.client_closed",
+		Title:    ClientClosedRequest,
+		Detail:   "The client closed the request before the server could send a response.",
+		Status:   StatusClientClosedRequest,
+		Code:     clientClosedRequest,
+		Instance: InstClient,
+		LogLevel: LevelNone,
+	},
 
 	// 500 ERRORS
-	case UnspecifiedFailure:
-		problem.Detail = "The request is rejected due to unspecified reason at the system!"
-		problem.Status = 500
-		problem.Code = internalServerError
-		problem.Instance = InstApi
-
-	//503 ERRORS
-	case ServiceUnavailable:
-		problem.Detail = "The service experiences congestion and performs overload control. It does not allow the request to be processed."
-		problem.Status = 503
-		problem.Code = serviceUnavailable
-		problem.Instance = InstApi
+	{
+		Name:     "UnspecifiedFailure",
+		ID:       "system.unspecified_failure",
+		Title:    UnspecifiedFailure,
+		Detail:   "The request is rejected due to unspecified reason at the system!",
+		Status:   500,
+		Code:     internalServerError,
+		Instance: InstApi,
+	},
+	systemFailure,
+
+	// 503 ERRORS
+	{
+		Name:     "ServiceUnavailable",
+		ID:       "service.unavailable",
+		Title:    ServiceUnavailable,
+		Detail:   "The service experiences congestion and performs overload control. It does not allow the request to be processed.",
+		Status:   503,
+		Code:     serviceUnavailable,
+		Instance: InstApi,
+	},
 
 	// 504 ERRORS
-	case GatewayTimeout:
-		problem.Detail = "The request is rejected due a request that has timed out at the HTTP client."
-		problem.Status = 504
-		problem.Code = gatewayTimeout
-		problem.Instance = InstApi
+	{
+		Name:     "GatewayTimeout",
+		ID:       "gateway.timeout",
+		Title:    GatewayTimeout,
+		Detail:   "The request is rejected due a request that has timed out at the HTTP client.",
+		Status:   504,
+		Code:     gatewayTimeout,
+		Instance: InstApi,
+	},
+}
 
-	// DEFUALT ERROR 500
-	default:
-		problem.Title = SystemFailure
-		problem.Detail = "We are sorry, but there is an internal problem with the application!" + supportText
-		problem.Status = 500
-		problem.Code = internalServerError
-		problem.Instance = InstApi
-	}
+// CreateProblemDetails - Helper function to create ProblemDetails object
+func CreateProblemDetails(errorName string) *models.ProblemDetails {
+	problem := DefaultCatalog.Problem(errorName)
+	DefaultSupport().Apply(problem)
 
 	return problem
 }
@@ -426,13 +820,9 @@ func writeResponse(problem *models.ProblemDetails, rw http.ResponseWriter) {
 	_, _ = rw.Write(data)
 }
 
-func unknownError(rw http.ResponseWriter, r *http.Request, err error) {
-
-	log.WithField("util", "errors").Errorf("Unknown error: %v", err.Error())
-
-	problem := CreateProblemDetails(SystemFailure)
-	problem.Type = r.RequestURI
-	writeResponse(problem, rw)
+func (s *Server) unknownError(rw http.ResponseWriter, r *http.Request, err error) {
+	problem := s.problem(r, SystemFailure)
+	s.write(rw, r, problem, err)
 }
 
 func flattenComposite(errs *errors.CompositeError) *errors.CompositeError {
@@ -465,172 +855,255 @@ func errorAsJSON(err errors.Error) []byte {
 
 // ServeError the error handler interface implementation
 func ServeError(rw http.ResponseWriter, r *http.Request, err error) {
-	rw.Header().Set("Content-Type", "application/json")
-
-	bodyProblem := CreateProblemDetails(InvalidBodyParam)
-	bodyProblem.Type = r.RequestURI
-
-	bodyMissingProblem := CreateProblemDetails(MandatoryParamMissing)
-	bodyMissingProblem.Type = r.RequestURI
-
-	queryProblem := CreateProblemDetails(InvalidQueryParam)
-	queryProblem.Type = r.RequestURI
+	DefaultServer.ServeError(rw, r, err)
+}
 
-	queryMissingProblem := CreateProblemDetails(MandatoryParamMissing)
-	queryMissingProblem.Type = r.RequestURI
+// Buckets of invalid params collected from a CompositeError. Their order
+// is the priority of the served problem: body problems first, then query
+// problems, missing parameters and the rest.
+const (
+	bodyBucket = iota
+	bodyMissingBucket
+	queryBucket
+	queryMissingBucket
+	pathBucket
+	headerBucket
+	formatBucket
+	bucketCount
+)
 
-	pathProblem := CreateProblemDetails(InvalidPathParam)
-	pathProblem.Type = r.RequestURI
+// bucketTitles are the problem titles of the buckets
+var bucketTitles = [bucketCount]string{
+	bodyBucket:         InvalidBodyParam,
+	bodyMissingBucket:  MandatoryParamMissing,
+	queryBucket:        InvalidQueryParam,
+	queryMissingBucket: MandatoryParamMissing,
+	pathBucket:         InvalidPathParam,
+	headerBucket:       InvalidHeaderParam,
+	formatBucket:       InvalidMsgFormat,
+}
 
-	headerProblem := CreateProblemDetails(InvalidHeaderParam)
-	headerProblem.Type = r.RequestURI
+// ServeError the error handler interface implementation.
+// The request may be nil. A nil error, including a typed nil pointer
+// stored in the error interface, is served as SystemFailure.
+func (s *Server) ServeError(rw http.ResponseWriter, r *http.Request, err error) {
+	rw.Header().Set("Content-Type", s.contentType)
 
-	problem := CreateProblemDetails(InvalidMsgFormat)
-	problem.Type = r.RequestURI
+	if isNil(err) {
+		err = nil
+	}
 
 	switch e := err.(type) {
 	case *errors.CompositeError:
 		// er := flattenComposite(e)
 		// log.Printf("er: %v", er.Errors)
 
+		var buckets [bucketCount][]*models.InvalidParam
+		items := make([]error, 0, len(e.Errors))
 		for _, errItem := range e.Errors {
+			// nil items are skipped like in flattenComposite
+			if isNil(errItem) {
+				continue
+			}
+			items = append(items, errItem)
 			switch valErr := errItem.(type) {
 			case *errors.Validation:
 				invalidParam := &models.InvalidParam{
 					Param:  &valErr.Name,
+					In:     valErr.In,
 					Reason: valErr.Error(),
 				}
+				bucket := formatBucket
 				switch valErr.In {
 				case "body":
 					// log.Printf("request body issue: %+v", valErr)
 					// log.Printf("valErr.code(): %v", valErr.Code())
-					if valErr.Name == "body" {
-						problem.InvalidParams = append(problem.InvalidParams, invalidParam)
-					} else {
+					if valErr.Name != "body" {
 						// Filter custom openapi errors
 						// More details - https://github.com/go-openapi/errors/blob/master/schema.go
 						if valErr.Code() == 602 {
-							bodyMissingProblem.InvalidParams = append(bodyMissingProblem.InvalidParams, invalidParam)
+							bucket = bodyMissingBucket
 						} else {
-							bodyProblem.InvalidParams = append(bodyProblem.InvalidParams, invalidParam)
+							bucket = bodyBucket
 						}
 					}
 
 				case "query":
 					if valErr.Code() == 602 {
-						queryMissingProblem.InvalidParams = append(queryMissingProblem.InvalidParams, invalidParam)
+						bucket = queryMissingBucket
 					} else {
-						queryProblem.InvalidParams = append(queryProblem.InvalidParams, invalidParam)
+						bucket = queryBucket
 					}
 
 				case "path":
-					pathProblem.InvalidParams = append(pathProblem.InvalidParams, invalidParam)
+					bucket = pathBucket
 
 				case "header":
-					headerProblem.InvalidParams = append(headerProblem.InvalidParams, invalidParam)
-
-				default:
-					problem.InvalidParams = append(problem.InvalidParams, invalidParam)
+					bucket = headerBucket
 				}
+				buckets[bucket] = append(buckets[bucket], invalidParam)
 
 			case *errors.ParseError:
+				if valErr.In != "body" {
+					s.ServeError(rw, r, valErr)
+					return
+				}
 				invalidParam := &models.InvalidParam{
 					Param:  &valErr.Name,
+					In:     valErr.In,
 					Reason: valErr.Error(),
 				}
-				switch valErr.In {
-				case "body":
-					if valErr.Name == "body" {
-						problem.InvalidParams = append(problem.InvalidParams, invalidParam)
+				bucket := formatBucket
+				if valErr.Name != "body" {
+					// Filter custom openapi errors
+					// More details - https://github.com/go-openapi/errors/blob/master/schema.go
+					if valErr.Code() == 602 {
+						bucket = bodyMissingBucket
 					} else {
-						// Filter custom openapi errors
-						// More details - https://github.com/go-openapi/errors/blob/master/schema.go
-						if valErr.Code() == 602 {
-							bodyMissingProblem.InvalidParams = append(bodyMissingProblem.InvalidParams, invalidParam)
-						} else {
-							bodyProblem.InvalidParams = append(bodyProblem.InvalidParams, invalidParam)
-						}
+						bucket = bodyBucket
 					}
-
-				default:
-					ServeError(rw, r, valErr)
-					return
 				}
+				buckets[bucket] = append(buckets[bucket], invalidParam)
 
 			default:
-				ServeError(rw, r, valErr)
+				s.ServeError(rw, r, valErr)
 				return
 			}
 		}
 
-		// The queue of the returned problem details is important
-		// first let return query problems
-		// second missing parameters
-		// and then the rest
-		if len(bodyProblem.InvalidParams) > 0 {
-			writeResponse(bodyProblem, rw)
-		} else if len(bodyMissingProblem.InvalidParams) > 0 {
-			writeResponse(bodyMissingProblem, rw)
-		} else if len(queryProblem.InvalidParams) > 0 {
-			writeResponse(queryProblem, rw)
-		} else if len(queryMissingProblem.InvalidParams) > 0 {
-			writeResponse(queryMissingProblem, rw)
-		} else if len(pathProblem.InvalidParams) > 0 {
-			writeResponse(pathProblem, rw)
-		} else if len(headerProblem.InvalidParams) > 0 {
-			writeResponse(headerProblem, rw)
-		} else if len(problem.InvalidParams) > 0 {
-			writeResponse(problem, rw)
-		} else {
-			ServeError(rw, r, nil)
+		// only the served problem is created
+		var served *models.ProblemDetails
+		for bucket, params := range buckets {
+			if len(params) == 0 {
+				continue
+			}
+			if served == nil {
+				served = s.problem(r, bucketTitles[bucket])
+				served.InvalidParams = params
+				if s.aggregation != AggregateAll {
+					break
+				}
+				continue
+			}
+			served.InvalidParams = append(served.InvalidParams, params...)
+		}
+
+		if served == nil {
+			s.ServeError(rw, r, nil)
+			return
+		}
+		if len(items) < len(e.Errors) {
+			// the message of a composite error with nil items can't be logged
+			err = errors.CompositeValidationError(items...)
 		}
+		s.write(rw, r, served, err)
+
+	case *Problem:
+		s.serveProblem(rw, r, e, err)
 
 	case *errors.MethodNotAllowedError:
-		rw.Header().Add("Allow", strings.Join(err.(*errors.MethodNotAllowedError).Allowed, ","))
+		rw.Header().Add("Allow", strings.Join(e.Allowed, ","))
 
-		methodNotAllowedProblem := CreateProblemDetails(MethodNotAllowed)
-		methodNotAllowedProblem.Type = r.RequestURI
-		if r == nil || r.Method != http.MethodHead {
-			writeResponse(methodNotAllowedProblem, rw)
-		}
+		methodNotAllowedProblem := s.problem(r, MethodNotAllowed)
+		s.write(rw, r, methodNotAllowedProblem, err)
 
 	// Default error handler
 	case errors.Error:
 
 		if e.Code() == 400 {
-			badRequestProblem := CreateProblemDetails(BadRequest)
-			badRequestProblem.Detail = fmt.Sprintf("%v %v", badRequestProblem.Detail, e.Error())
-			badRequestProblem.Type = r.RequestURI
-			writeResponse(badRequestProblem, rw)
+			badRequestProblem := s.problem(r, BadRequest)
+			if s.exposure == ExposeErrors {
+				badRequestProblem.Detail = fmt.Sprintf("%v %v", badRequestProblem.Detail, e.Error())
+			}
+			s.write(rw, r, badRequestProblem, err)
 			return
 		}
 
 		if e.Code() == 401 {
-			notAuthorizedProblem := CreateProblemDetails(UnauthorizedAccess)
-			notAuthorizedProblem.Type = r.RequestURI
-			writeResponse(notAuthorizedProblem, rw)
+			notAuthorizedProblem := s.problem(r, UnauthorizedAccess)
+			s.write(rw, r, notAuthorizedProblem, err)
 			return
 		}
 
 		if e.Code() == 404 {
-			notFoundProblem := CreateProblemDetails(ResourceNotFound)
-			notFoundProblem.Type = r.RequestURI
-			notFoundProblem.Detail = notFoundProblem.Detail + " " + e.Error()
-			writeResponse(notFoundProblem, rw)
+			notFoundProblem := s.problem(r, ResourceNotFound)
+			if s.exposure == ExposeErrors {
+				notFoundProblem.Detail = notFoundProblem.Detail + " " + e.Error()
+			}
+			s.write(rw, r, notFoundProblem, err)
 			return
 		}
 
-		value := reflect.ValueOf(e)
-		//log.Printf("error value: %v, error code: %v", value, e.Code())
-		if value.Kind() == reflect.Ptr && value.IsNil() {
-			unknownError(rw, r, err)
-			return
-		}
-		unknownError(rw, r, err)
+		s.unknownError(rw, r, err)
 
 	case nil:
-		unknownError(rw, r, err)
+		s.unknownError(rw, r, err)
 	default:
-		unknownError(rw, r, err)
+		var p *Problem
+		if stderrors.As(err, &p) {
+			s.serveProblem(rw, r, p, err)
+			return
+		}
+		if title, ok := contextTitle(err); ok {
+			s.write(rw, r, s.problem(r, title), err)
+			return
+		}
+		s.unknownError(rw, r, err)
+	}
+}
+
+// isNil reports whether err is nil or a nil pointer stored in the error interface
+func isNil(err error) bool {
+	if err == nil {
+		return true
+	}
+	value := reflect.ValueOf(err)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+// serveProblem writes a copy of the Problem error found in err
+func (s *Server) serveProblem(rw http.ResponseWriter, r *http.Request, p *Problem, err error) {
+	if p == nil || p.ProblemDetails == nil {
+		s.unknownError(rw, r, err)
+		return
+	}
+
+	for key, values := range p.Header {
+		for _, value := range values {
+			rw.Header().Add(key, value)
+		}
+	}
+
+	problem := copyProblemDetails(p.ProblemDetails)
+	problem.Type = s.problemType(r, problem)
+	s.localize(r, problem)
+	s.write(rw, r, problem, err)
+}
+
+// copyProblemDetails deep copies the problem, so the served problem can be changed
+// by hooks while the same Problem error is served concurrently
+func copyProblemDetails(details *models.ProblemDetails) *models.ProblemDetails {
+	problem := *details
+
+	if details.ProblemDetailsAdditionalProperties != nil {
+		problem.ProblemDetailsAdditionalProperties = make(map[string]interface{}, len(details.ProblemDetailsAdditionalProperties))
+		for k, v := range details.ProblemDetailsAdditionalProperties {
+			problem.ProblemDetailsAdditionalProperties[k] = v
+		}
+	}
+
+	if details.InvalidParams != nil {
+		problem.InvalidParams = make([]*models.InvalidParam, len(details.InvalidParams))
+		for i, param := range details.InvalidParams {
+			if param != nil {
+				copied := *param
+				problem.InvalidParams[i] = &copied
+			}
+		}
 	}
+	return &problem
 }
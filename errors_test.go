@@ -94,7 +94,7 @@ func Test_flattenComposite(t *testing.T) {
 
 func Test_unknownError(t *testing.T) {
 	emptyError := func(w http.ResponseWriter, r *http.Request) {
-		unknownError(w, r, errors.New(""))
+		DefaultServer.unknownError(w, r, errors.New(""))
 	}
 
 	handler := http.HandlerFunc(emptyError)
@@ -116,3 +116,42 @@ func Test_writeResponse(t *testing.T) {
 	EqualValues(t, http.StatusInternalServerError, rr.Code)
 	Equal(t, `{"status":500}`, rr.Body.String())
 }
+
+func TestServeError_typedNil(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger))
+	for _, err := range []error{
+		(*cer.Validation)(nil),
+		(*cer.CompositeError)(nil),
+		(*cer.MethodNotAllowedError)(nil),
+		(*Problem)(nil),
+		cer.CompositeValidationError((*cer.Validation)(nil)),
+	} {
+		rr := httptest.NewRecorder()
+		NotPanics(t, func() { s.ServeError(rr, nil, err) })
+		EqualValues(t, http.StatusInternalServerError, rr.Code)
+		Contains(t, rr.Body.String(), SystemFailure)
+	}
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, nil, cer.CompositeValidationError(cer.Required("name", "body", nil), nil, (*cer.Validation)(nil)))
+	EqualValues(t, http.StatusBadRequest, rr.Code)
+	Contains(t, rr.Body.String(), MandatoryParamMissing)
+}
+
+func TestServeError_createsServedProblemOnly(t *testing.T) {
+	var created int
+	s := NewServer(WithLogger(NopLogger), WithLocalizer(LocalizerFunc(func(*http.Request, *models.ProblemDetails) {
+		created++
+	})))
+
+	rr := httptest.NewRecorder()
+	err := cer.CompositeValidationError(
+		cer.InvalidType("limit", "query", "integer", "x"),
+		cer.Required("name", "body", nil),
+	)
+	s.ServeError(rr, httptest.NewRequest(http.MethodGet, "/", nil), err)
+
+	EqualValues(t, http.StatusBadRequest, rr.Code)
+	Contains(t, rr.Body.String(), MandatoryParamMissing)
+	Equal(t, 1, created)
+}
@@ -0,0 +1,89 @@
+// Package errorstest provides assertions of problem responses for handler tests
+//
+//	rr := httptest.NewRecorder()
+//	handler.ServeHTTP(rr, r)
+//	errorstest.AssertProblem(t, rr, errors.ListingNotFound)
+package errorstest
+
+import (
+	"mime"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kerrors "github.com/Kviky/errors"
+	"github.com/Kviky/errors/models"
+)
+
+// expected are the fields compared by AssertProblem
+type expected struct {
+	Status   int32
+	Title    string
+	Code     string
+	Instance string
+}
+
+// DecodeProblem decodes a ProblemDetails body. The test fails when the body is not valid.
+func DecodeProblem(t testing.TB, body []byte) *models.ProblemDetails {
+	t.Helper()
+
+	problem := &models.ProblemDetails{}
+	if err := problem.UnmarshalBinary(body); err != nil {
+		t.Fatalf("body is not ProblemDetails: %v\n%s", err, body)
+	}
+	return problem
+}
+
+// AssertProblem checks the recorded response is the problem with given title of the
+// DefaultCatalog: its status, content type, title, code and instance. It returns the
+// decoded problem for further checks.
+func AssertProblem(t testing.TB, rr *httptest.ResponseRecorder, title string) *models.ProblemDetails {
+	t.Helper()
+
+	entry, ok := kerrors.DefaultCatalog.Lookup(title)
+	if !ok {
+		t.Fatalf("problem %q is not registered in the catalog", title)
+	}
+
+	contentType := rr.Header().Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	assert.True(t, mediaType == "application/json" || mediaType == "application/problem+json",
+		"unexpected Content-Type %q", contentType)
+
+	problem := DecodeProblem(t, rr.Body.Bytes())
+	assert.EqualValues(t, entry.Status, rr.Code, "response status")
+	assert.Equal(t,
+		expected{Status: entry.Status, Title: entry.Title, Code: entry.Code, Instance: entry.Instance},
+		expected{Status: problem.Status, Title: problem.Title, Code: problem.Code, Instance: problem.Instance},
+		"problem %q", title)
+	return problem
+}
+
+// AssertInvalidParam checks the recorded problem reports given param in given location
+// (body, query, path, header) and returns it. An empty location matches any location.
+func AssertInvalidParam(t testing.TB, rr *httptest.ResponseRecorder, param, in string) *models.InvalidParam {
+	t.Helper()
+
+	problem := DecodeProblem(t, rr.Body.Bytes())
+
+	var names []string
+	for _, p := range problem.InvalidParams {
+		if p == nil || p.Param == nil {
+			continue
+		}
+		name := *p.Param
+		if p.In != "" {
+			name += " in " + p.In
+		}
+		names = append(names, name)
+		if *p.Param == param && (in == "" || p.In == in) {
+			return p
+		}
+	}
+
+	assert.Fail(t, "invalid param not found",
+		"expected invalid param %q, got [%s]", param, strings.Join(names, ", "))
+	return nil
+}
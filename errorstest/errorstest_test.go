@@ -0,0 +1,104 @@
+package errorstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+
+	cer "github.com/go-openapi/errors"
+	. "github.com/stretchr/testify/assert"
+
+	kerrors "github.com/Kviky/errors"
+)
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Name() string {
+	return "recorder"
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// run runs f in a separate goroutine, so Fatalf can stop it
+func (r *recorder) run(f func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f()
+	}()
+	wg.Wait()
+}
+
+func serve(err error) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/listings", nil)
+	kerrors.NewServer(kerrors.WithLogger(kerrors.NopLogger)).ServeError(rr, r, err)
+	return rr
+}
+
+func TestAssertProblem(t *testing.T) {
+	rr := serve(kerrors.NewProblem(kerrors.ListingNotFound))
+	problem := AssertProblem(t, rr, kerrors.ListingNotFound)
+	Equal(t, "/listings", problem.Type)
+
+	rec := &recorder{}
+	rec.run(func() { AssertProblem(rec, rr, kerrors.UserNotFound) })
+	Len(t, rec.failures, 1)
+	Contains(t, rec.failures[0], "User not found!")
+
+	rec = &recorder{}
+	rec.run(func() { AssertProblem(rec, rr, "Unknown!") })
+	Len(t, rec.failures, 1)
+}
+
+func TestAssertInvalidParam(t *testing.T) {
+	rr := serve(cer.CompositeValidationError(
+		cer.InvalidType("email", "body", "string", ""),
+		cer.Required("name", "body", nil),
+	))
+	AssertProblem(t, rr, kerrors.InvalidBodyParam)
+	param := AssertInvalidParam(t, rr, "email", "body")
+	NotNil(t, param)
+	AssertInvalidParam(t, rr, "email", "")
+
+	rec := &recorder{}
+	rec.run(func() { AssertInvalidParam(rec, rr, "email", "query") })
+	Len(t, rec.failures, 1)
+
+	rec = &recorder{}
+	rec.run(func() { AssertInvalidParam(rec, rr, "phone", "body") })
+	Len(t, rec.failures, 1)
+	Contains(t, rec.failures[0], "got [email in body]")
+
+	rr = serve(cer.CompositeValidationError(cer.InvalidType("listing_id", "path", "integer", "x")))
+	rec = &recorder{}
+	rec.run(func() { AssertInvalidParam(rec, rr, "id", "path") })
+	Len(t, rec.failures, 1)
+	AssertInvalidParam(t, rr, "listing_id", "path")
+}
+
+func TestDecodeProblem(t *testing.T) {
+	problem := DecodeProblem(t, []byte(`{"title":"Bad request!","status":400}`))
+	Equal(t, kerrors.BadRequest, problem.Title)
+
+	rec := &recorder{}
+	rec.run(func() { DecodeProblem(rec, []byte(`<html>`)) })
+	Len(t, rec.failures, 1)
+}
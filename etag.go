@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Extension members of PreconditionFailed problems
+const (
+	ExtETag    = "etag"
+	ExtVersion = "version"
+)
+
+// NewPreconditionFailed creates a PreconditionFailed problem carrying the current
+// ETag and version of the resource. The ETag is also set as the response header.
+// A nil version is omitted.
+func NewPreconditionFailed(etag string, version interface{}) *Problem {
+	etag = quoteETag(etag)
+
+	p := NewProblem(PreconditionFailed)
+	if p.ProblemDetailsAdditionalProperties == nil {
+		p.ProblemDetailsAdditionalProperties = make(map[string]interface{})
+	}
+	p.ProblemDetailsAdditionalProperties[ExtETag] = etag
+	if version != nil {
+		p.ProblemDetailsAdditionalProperties[ExtVersion] = version
+	}
+	p.Header = make(http.Header)
+	p.Header.Set("ETag", etag)
+	return p
+}
+
+// CheckIfMatch checks the If-Match header of an update against the current ETag
+// of the resource. It returns PreconditionRequired when the header or the request
+// is missing and PreconditionFailed when no listed tag matches the current one.
+func CheckIfMatch(r *http.Request, etag string, version interface{}) error {
+	if r == nil {
+		return NewProblem(PreconditionRequired)
+	}
+
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		return NewProblem(PreconditionRequired)
+	}
+	if ifMatch == "*" {
+		return nil
+	}
+
+	// If-Match uses the strong comparison, weak tags never match
+	current := quoteETag(etag)
+	if !strings.HasPrefix(current, "W/") {
+		for _, tag := range strings.Split(ifMatch, ",") {
+			if strings.TrimSpace(tag) == current {
+				return nil
+			}
+		}
+	}
+	return NewPreconditionFailed(etag, version)
+}
+
+// quoteETag adds quotes to an ETag unless it has them already
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestNewPreconditionFailed(t *testing.T) {
+	p := NewPreconditionFailed("v7", 7)
+	EqualValues(t, http.StatusPreconditionFailed, p.Status)
+	Equal(t, `"v7"`, p.Header.Get("ETag"))
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/listings/42", nil)
+	ServeError(rr, r, p)
+
+	EqualValues(t, http.StatusPreconditionFailed, rr.Code)
+	Equal(t, `"v7"`, rr.Header().Get("ETag"))
+
+	details := &models.ProblemDetails{}
+	NoError(t, details.UnmarshalBinary(rr.Body.Bytes()))
+	Equal(t, PreconditionFailed, details.Title)
+	Equal(t, map[string]interface{}{"id": "precondition.failed", "etag": `"v7"`, "version": float64(7)}, details.ProblemDetailsAdditionalProperties)
+
+	p = NewPreconditionFailed(`W/"abc"`, nil)
+	Equal(t, map[string]interface{}{"id": "precondition.failed", "etag": `W/"abc"`}, p.ProblemDetailsAdditionalProperties)
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/listings/42", nil)
+	True(t, Is(CheckIfMatch(r, "v7", 7), PreconditionRequired))
+	True(t, Is(CheckIfMatch(nil, "v7", 7), PreconditionRequired))
+
+	r.Header.Set("If-Match", `"v6"`)
+	err := CheckIfMatch(r, "v7", 7)
+	True(t, Is(err, PreconditionFailed))
+
+	r.Header.Set("If-Match", `"v6", "v7"`)
+	NoError(t, CheckIfMatch(r, "v7", 7))
+
+	r.Header.Set("If-Match", `W/"v7"`)
+	True(t, Is(CheckIfMatch(r, "v7", 7), PreconditionFailed))
+
+	r.Header.Set("If-Match", "*")
+	NoError(t, CheckIfMatch(r, "v7", 7))
+}
@@ -0,0 +1,140 @@
+//go:build go1.18
+// +build go1.18
+
+package errors
+
+import (
+	"bytes"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cer "github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/Kviky/errors/models"
+)
+
+// fuzzWriter counts WriteHeader calls including the implicit one of Write
+type fuzzWriter struct {
+	header  http.Header
+	status  int
+	headers int
+	body    bytes.Buffer
+}
+
+func (w *fuzzWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *fuzzWriter) WriteHeader(status int) {
+	w.headers++
+	w.status = status
+}
+
+func (w *fuzzWriter) Write(b []byte) (int, error) {
+	if w.headers == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(b)
+}
+
+// errorTree builds go-openapi error trees from fuzz input
+type errorTree struct {
+	data []byte
+}
+
+func (t *errorTree) next() int {
+	if len(t.data) == 0 {
+		return 0
+	}
+	b := t.data[0]
+	t.data = t.data[1:]
+	return int(b)
+}
+
+func (t *errorTree) pick(values ...string) string {
+	return values[t.next()%len(values)]
+}
+
+func (t *errorTree) build(depth int) error {
+	name := t.pick("body", "email", "limit", "id", "X-Request-Id", "")
+	in := t.pick("body", "query", "path", "header", "formData", "")
+
+	switch t.next() % 8 {
+	case 0:
+		return nil
+	case 1:
+		if depth > 3 {
+			return nil
+		}
+		var errs []error
+		for i := t.next() % 4; i > 0; i-- {
+			errs = append(errs, t.build(depth+1))
+		}
+		return cer.CompositeValidationError(errs...)
+	case 2:
+		return cer.InvalidType(name, in, "integer", "a")
+	case 3:
+		return cer.Required(name, in, nil)
+	case 4:
+		return cer.NewParseError(name, in, "a", stderrors.New("invalid syntax"))
+	case 5:
+		return cer.MethodNotAllowed(t.pick("GET", "POST", "HEAD"), []string{"GET", "PUT"})
+	case 6:
+		return stderrors.New("plain error")
+	default:
+		codes := []int32{400, 401, 403, 404, 422, 500, 503}
+		return cer.New(codes[t.next()%len(codes)], "openapi error")
+	}
+}
+
+func FuzzServeError(f *testing.F) {
+	f.Add([]byte{}, false, false)
+	f.Add([]byte{0, 0, 1, 3, 0, 0, 2, 1, 1, 3}, false, false)
+	f.Add([]byte{0, 0, 1, 0}, false, false)
+	f.Add([]byte{0, 0, 1, 1, 0, 0, 5}, false, true)
+	f.Add([]byte{0, 0, 1, 2, 0, 0, 0, 0, 0, 4}, true, false)
+	f.Add([]byte{0, 0, 6}, true, false)
+	f.Add([]byte{0, 0, 7, 3}, false, false)
+
+	s := NewServer(WithLogger(NopLogger))
+
+	f.Fuzz(func(t *testing.T, data []byte, nilRequest, head bool) {
+		err := (&errorTree{data: data}).build(0)
+
+		var r *http.Request
+		if !nilRequest {
+			method := http.MethodPost
+			if head {
+				method = http.MethodHead
+			}
+			r = httptest.NewRequest(method, "/listings?limit=a", nil)
+		}
+
+		w := &fuzzWriter{header: make(http.Header)}
+		s.ServeError(w, r, err)
+
+		if w.headers != 1 {
+			t.Fatalf("WriteHeader called %d times for %v", w.headers, err)
+		}
+		if w.status < 400 || w.status > 599 {
+			t.Fatalf("unexpected status %d for %v", w.status, err)
+		}
+		if r != nil && r.Method == http.MethodHead {
+			return
+		}
+
+		problem := &models.ProblemDetails{}
+		if err := problem.UnmarshalBinary(w.body.Bytes()); err != nil {
+			t.Fatalf("invalid problem %q: %v", w.body.String(), err)
+		}
+		if int(problem.Status) != w.status {
+			t.Fatalf("problem status %d doesn't match response status %d", problem.Status, w.status)
+		}
+		if err := problem.Validate(strfmt.Default); err != nil {
+			t.Fatalf("invalid problem %q: %v", w.body.String(), err)
+		}
+	})
+}
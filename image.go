@@ -0,0 +1,136 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	// register decoders of supported image types
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+)
+
+// ImageLimits are the constraints of uploaded images. Zero limits are not checked.
+type ImageLimits struct {
+	// MaxSizeMB is the maximum file size in megabytes
+	MaxSizeMB int64
+	// MaxWidth is the maximum width in pixels
+	MaxWidth int
+	// MaxHeight is the maximum height in pixels
+	MaxHeight int
+	// Types are the allowed MIME types, image/jpeg and image/png when empty
+	Types []string
+}
+
+// defaultImageTypes are types with a registered decoder
+var defaultImageTypes = []string{"image/jpeg", "image/png"}
+
+// CheckFileHeader checks an uploaded multipart file. It returns ImageNotUploaded
+// for a nil file, ImageInvalid with InvalidParams of all violated limits, or nil.
+func (l ImageLimits) CheckFileHeader(fh *multipart.FileHeader) error {
+	if fh == nil {
+		return NewProblem(ImageNotUploaded)
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return Wrap(err, ImageNotUploaded)
+	}
+	defer f.Close()
+
+	return l.check(f, func() (int64, error) { return fh.Size, nil })
+}
+
+// Check reads an image and checks it. Only the header of the image is decoded and
+// the rest is read up to the size limit, so the image is never held in memory.
+// It returns ImageInvalid with InvalidParams of all violated limits, or nil.
+func (l ImageLimits) Check(r io.Reader) error {
+	cr := &countingReader{r: r}
+	if l.MaxSizeMB > 0 {
+		cr.r = io.LimitReader(r, l.maxSize()+1)
+	}
+
+	return l.check(cr, func() (int64, error) {
+		_, err := io.Copy(ioutil.Discard, cr)
+		return cr.n, err
+	})
+}
+
+// check checks the image read from r. size is called after the image header
+// is decoded and only when MaxSizeMB is set.
+func (l ImageLimits) check(r io.Reader, size func() (int64, error)) error {
+	v := NewValidator(ImageInvalid)
+
+	// The content type is sniffed, as the one declared by the client can't be trusted
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(r, head)
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	allowed := l.allowed(contentType)
+
+	var config image.Config
+	var decodeErr error
+	if allowed {
+		config, _, decodeErr = image.DecodeConfig(io.MultiReader(bytes.NewReader(head), r))
+	}
+
+	if l.MaxSizeMB > 0 {
+		total, err := size()
+		if err != nil {
+			return Wrap(err, ImageNotUploaded)
+		}
+		if total > l.maxSize() {
+			v.AddParam(NewImageSizeError(l.MaxSizeMB))
+		}
+	}
+
+	if !allowed {
+		v.Add("type", fmt.Sprintf("Unsupported content type %s", contentType))
+		return v.Err()
+	}
+	if decodeErr != nil {
+		v.Add("file", "File is not a valid image")
+		return v.Err()
+	}
+
+	if l.MaxWidth > 0 && config.Width > l.MaxWidth {
+		v.AddParam(NewImageSizePxError("width", l.MaxWidth))
+	}
+	if l.MaxHeight > 0 && config.Height > l.MaxHeight {
+		v.AddParam(NewImageSizePxError("height", l.MaxHeight))
+	}
+	return v.Err()
+}
+
+func (l ImageLimits) allowed(contentType string) bool {
+	types := l.Types
+	if len(types) == 0 {
+		types = defaultImageTypes
+	}
+	for _, t := range types {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+func (l ImageLimits) maxSize() int64 {
+	return l.MaxSizeMB << 20
+}
+
+// countingReader counts the bytes read
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func invalidParams(t *testing.T, err error) map[string]string {
+	var p *Problem
+	True(t, errors.As(err, &p))
+	Equal(t, ImageInvalid, p.Title)
+
+	params := make(map[string]string)
+	for _, param := range p.InvalidParams {
+		params[*param.Param] = param.Reason
+	}
+	return params
+}
+
+// failingReader fails every read, so reads past the size limit are detected
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read past the limit")
+}
+
+func TestImageLimits_Check(t *testing.T) {
+	limits := ImageLimits{MaxSizeMB: 1, MaxWidth: 100, MaxHeight: 50}
+
+	NoError(t, limits.Check(bytes.NewReader(encodePNG(t, 100, 50))))
+
+	var buf bytes.Buffer
+	NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10)), nil))
+	NoError(t, limits.Check(&buf))
+
+	err := limits.Check(bytes.NewReader(encodePNG(t, 101, 51)))
+	Equal(t, map[string]string{
+		"width":  "Maximum width is 100px",
+		"height": "Maximum height is 50px",
+	}, invalidParams(t, err))
+
+	err = limits.Check(strings.NewReader("GIF89a"))
+	Equal(t, map[string]string{"type": "Unsupported content type image/gif"}, invalidParams(t, err))
+
+	data := encodePNG(t, 10, 10)
+	err = limits.Check(bytes.NewReader(data[:len(data)/4]))
+	Equal(t, map[string]string{"file": "File is not a valid image"}, invalidParams(t, err))
+
+	large := append(encodePNG(t, 10, 10), make([]byte, 1<<20)...)
+	err = limits.Check(bytes.NewReader(large))
+	Equal(t, map[string]string{"size": "Maximum file size is 1MB"}, invalidParams(t, err))
+
+	NoError(t, ImageLimits{}.Check(bytes.NewReader(large)))
+	err = limits.Check(io.MultiReader(bytes.NewReader(large), failingReader{}))
+	Equal(t, map[string]string{"size": "Maximum file size is 1MB"}, invalidParams(t, err))
+
+	err = limits.Check(io.MultiReader(bytes.NewReader(data), failingReader{}))
+	True(t, Is(err, ImageNotUploaded))
+	err = ImageLimits{Types: []string{"image/jpeg"}}.Check(bytes.NewReader(data))
+	Equal(t, map[string]string{"type": "Unsupported content type image/png"}, invalidParams(t, err))
+}
+
+func TestImageLimits_CheckFileHeader(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("image", "boat.png")
+	NoError(t, err)
+	_, _ = fw.Write(encodePNG(t, 200, 10))
+	NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/images", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	NoError(t, r.ParseMultipartForm(1<<20))
+
+	fh := r.MultipartForm.File["image"][0]
+	NoError(t, ImageLimits{MaxWidth: 200}.CheckFileHeader(fh))
+
+	err = ImageLimits{MaxWidth: 100}.CheckFileHeader(fh)
+	Equal(t, map[string]string{"width": "Maximum width is 100px"}, invalidParams(t, err))
+
+	True(t, Is(ImageLimits{}.CheckFileHeader(nil), ImageNotUploaded))
+}
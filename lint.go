@@ -0,0 +1,188 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severity of a lint issue
+type Severity int
+
+const (
+	// SeverityError fails the lint
+	SeverityError Severity = iota
+	// SeverityWarning is reported but fails the lint only in strict mode
+	SeverityWarning
+)
+
+// String returns the lower-case name of the severity
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Names of the lint checks
+const (
+	CheckDuplicate   = "duplicate"
+	CheckID          = "id"
+	CheckVerbs       = "verbs"
+	CheckSpelling    = "spelling"
+	CheckStatus      = "status"
+	CheckTranslation = "translation"
+)
+
+// LintIssue is a problem of a catalog entry found by Lint
+type LintIssue struct {
+	Title    string
+	Check    string
+	Severity Severity
+	Message  string
+}
+
+// String formats the issue for humans
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s [%s] %s", i.Severity, i.Title, i.Check, i.Message)
+}
+
+// Translation is a translated title and detail of a problem
+type Translation struct {
+	Title  string `json:"title" yaml:"title"`
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// Translations holds translations of problems by language and title
+type Translations map[string]map[string]Translation
+
+// nonstandardStatusText holds codes of statuses unknown to net/http
+var nonstandardStatusText = map[int32]string{
+	StatusClientClosedRequest: clientClosedRequest,
+}
+
+// misspellings are common typos of problem texts and their corrections
+var misspellings = map[string]string{
+	"accound":     "account",
+	"adress":      "address",
+	"existance":   "existence",
+	"occured":     "occurred",
+	"permision":   "permission",
+	"recieve":     "receive",
+	"seperate":    "separate",
+	"succesful":   "successful",
+	"unathorized": "unauthorized",
+}
+
+// verbPattern matches fmt verbs like %v, %s or %5.2f
+var verbPattern = regexp.MustCompile(`%[-+#0]*[0-9]*(\.[0-9]+)?[a-zA-Z]`)
+
+// idPattern matches stable identifiers like listing.not_found
+var idPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+
+// wordPattern splits texts into words
+var wordPattern = regexp.MustCompile(`[a-zA-Z]+`)
+
+// statusText returns the code expected for given status
+func statusText(status int32) string {
+	if text, ok := nonstandardStatusText[status]; ok {
+		return text
+	}
+	return http.StatusText(int(status))
+}
+
+// Lint checks entries for duplicate titles, names and IDs, malformed IDs, spelling mistakes, statuses not
+// matching their code and missing translations. Details with fmt verbs are errors
+// unless the entry is a Template filled by NewProblemf.
+func Lint(entries []Entry, translations Translations) []LintIssue {
+	var issues []LintIssue
+	report := func(title, check string, severity Severity, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{
+			Title:    title,
+			Check:    check,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	seen := make(map[string]bool, len(entries))
+	names := make(map[string]bool, len(entries))
+	ids := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.Title] {
+			report(e.Title, CheckDuplicate, SeverityError, "title is registered more than once")
+		}
+		seen[e.Title] = true
+
+		if e.Name != "" && names[e.Name] {
+			report(e.Title, CheckDuplicate, SeverityError, "name %s is used more than once", e.Name)
+		}
+		names[e.Name] = true
+
+		switch {
+		case e.ID == "":
+			report(e.Title, CheckID, SeverityWarning, "problem has no stable ID")
+		case !idPattern.MatchString(e.ID):
+			report(e.Title, CheckID, SeverityError, "ID %q is not like resource.condition", e.ID)
+		case ids[e.ID]:
+			report(e.Title, CheckDuplicate, SeverityError, "ID %s is used more than once", e.ID)
+		}
+		ids[e.ID] = true
+
+		if verbs := verbPattern.FindAllString(e.Detail, -1); len(verbs) > 0 && !e.Template {
+			report(e.Title, CheckVerbs, SeverityError, "detail has unfilled verbs %s", strings.Join(verbs, " "))
+		}
+
+		for _, text := range []string{e.Title, e.Detail} {
+			for _, word := range wordPattern.FindAllString(text, -1) {
+				if fix, ok := misspellings[strings.ToLower(word)]; ok {
+					report(e.Title, CheckSpelling, SeverityError, "%q should be %q", word, fix)
+				}
+			}
+		}
+
+		if e.Status < http.StatusBadRequest || e.Status > 599 {
+			report(e.Title, CheckStatus, SeverityError, "status %d is not 4xx or 5xx", e.Status)
+		} else if text := statusText(e.Status); text != "" && e.Code != text {
+			report(e.Title, CheckStatus, SeverityError, "code %q doesn't match status %d %q", e.Code, e.Status, text)
+		}
+	}
+
+	languages := make([]string, 0, len(translations))
+	for lang := range translations {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+
+	for _, lang := range languages {
+		for _, e := range entries {
+			if _, ok := translations[lang][e.Title]; !ok {
+				report(e.Title, CheckTranslation, SeverityError, "missing %s translation", lang)
+			}
+		}
+
+		var stale []string
+		for title := range translations[lang] {
+			if !seen[title] {
+				stale = append(stale, title)
+			}
+		}
+		sort.Strings(stale)
+		for _, title := range stale {
+			report(title, CheckTranslation, SeverityWarning, "%s translation of unknown problem", lang)
+		}
+	}
+	return issues
+}
+
+// LintFailed reports whether issues fail the lint. Warnings fail it in strict mode only.
+func LintFailed(issues []LintIssue, strict bool) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError || strict {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	entries := []Entry{
+		{ID: "a.one", Title: "A", Detail: "Listing %v is gone", Status: 404, Code: notFound},
+		{ID: "a.two", Title: "A", Detail: "This accound is locked", Status: 403, Code: notFound},
+		{ID: "b.one", Title: "B", Detail: "100% sure", Status: 499, Code: clientClosedRequest},
+		{ID: "c.one", Title: "C", Status: 200, Code: "OK"},
+	}
+	issues := Lint(entries, Translations{"hr": {
+		"A": {Title: "A", Detail: "Oglas %v ne postoji"},
+		"X": {Title: "X"},
+	}})
+
+	var checks []string
+	for _, i := range issues {
+		checks = append(checks, i.Check+" "+i.Title)
+	}
+	Equal(t, []string{
+		"verbs A",
+		"duplicate A",
+		"spelling A",
+		"status A",
+		"status C",
+		"translation B",
+		"translation C",
+		"translation X",
+	}, checks)
+	Equal(t, `error: A [spelling] "accound" should be "account"`, issues[2].String())
+	True(t, LintFailed(issues, false))
+}
+
+func TestLint_duplicateName(t *testing.T) {
+	issues := Lint([]Entry{
+		{Name: "A", ID: "a.b", Title: "A", Status: 400, Code: badRequest},
+		{Name: "A", ID: "a.b", Title: "B", Status: 400, Code: badRequest},
+	}, nil)
+	Len(t, issues, 2)
+	Equal(t, "error: B [duplicate] name A is used more than once", issues[0].String())
+	Equal(t, "error: B [duplicate] ID a.b is used more than once", issues[1].String())
+}
+
+func TestLint_id(t *testing.T) {
+	issues := Lint([]Entry{
+		{ID: "Listing-Not-Found", Title: "A", Status: 400, Code: badRequest},
+		{Title: "B", Status: 400, Code: badRequest},
+	}, nil)
+	Len(t, issues, 2)
+	Equal(t, `error: A [id] ID "Listing-Not-Found" is not like resource.condition`, issues[0].String())
+	Equal(t, "warning: B [id] problem has no stable ID", issues[1].String())
+}
+
+func TestLint_defaultEntries(t *testing.T) {
+	Empty(t, Lint(DefaultEntries(), nil))
+	False(t, LintFailed(nil, true))
+}
+
+func TestLint_verbs(t *testing.T) {
+	issues := Lint([]Entry{{ID: "listing.inactive", Title: "A", Detail: "Listing %v is not active", Status: 400, Code: badRequest}}, nil)
+	Len(t, issues, 1)
+	Equal(t, "error: A [verbs] detail has unfilled verbs %v", issues[0].String())
+	True(t, LintFailed(issues, false))
+
+	Empty(t, Lint([]Entry{{ID: "listing.inactive", Title: "A", Detail: "Listing %v is not active", Status: 400, Code: badRequest, Template: true}}, nil))
+}
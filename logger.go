@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Level is the severity of a log entry. The zero Level is unset.
+type Level int
+
+// Log levels
+const (
+	LevelDebug Level = iota + 1
+	LevelInfo
+	LevelWarn
+	LevelError
+	// LevelNone disables logging
+	LevelNone
+)
+
+// String returns the lower-case name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	case LevelNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (l Level) MarshalText() ([]byte, error) {
+	if l == 0 {
+		return []byte{}, nil
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "":
+		*l = 0
+	case "debug":
+		*l = LevelDebug
+	case "info":
+		*l = LevelInfo
+	case "warn":
+		*l = LevelWarn
+	case "error":
+		*l = LevelError
+	case "none":
+		*l = LevelNone
+	default:
+		return fmt.Errorf("unknown log level %q", text)
+	}
+	return nil
+}
+
+// LevelForStatus returns the log level of a problem with given status.
+// 429 and 503 are logged as warnings, other 5xx as errors and 4xx as info.
+func LevelForStatus(status int32) Level {
+	switch {
+	case status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable:
+		return LevelWarn
+	case status >= http.StatusInternalServerError:
+		return LevelError
+	case status >= http.StatusBadRequest:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
+
+// Fields are structured data attached to a log entry
+type Fields map[string]interface{}
+
+// Logger is the logging interface used by the package
+type Logger interface {
+	Log(level Level, msg string, fields Fields)
+}
+
+// LoggerFunc is an adapter to use ordinary functions as Logger
+type LoggerFunc func(level Level, msg string, fields Fields)
+
+// Log calls f(level, msg, fields)
+func (f LoggerFunc) Log(level Level, msg string, fields Fields) {
+	f(level, msg, fields)
+}
+
+// NopLogger discards all entries. Useful to disable logging in tests.
+var NopLogger Logger = LoggerFunc(func(Level, string, Fields) {})
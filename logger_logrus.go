@@ -0,0 +1,30 @@
+package errors
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type logrusLogger struct {
+	logger log.FieldLogger
+}
+
+// NewLogrusLogger adapts a logrus logger to the Logger interface
+func NewLogrusLogger(logger log.FieldLogger) Logger {
+	return &logrusLogger{logger: logger}
+}
+
+// Log implements Logger
+func (l *logrusLogger) Log(level Level, msg string, fields Fields) {
+	entry := l.logger.WithFields(log.Fields(fields))
+
+	switch level {
+	case LevelDebug:
+		entry.Debug(msg)
+	case LevelInfo:
+		entry.Info(msg)
+	case LevelWarn:
+		entry.Warn(msg)
+	default:
+		entry.Error(msg)
+	}
+}
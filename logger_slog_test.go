@@ -0,0 +1,27 @@
+//go:build go1.21
+// +build go1.21
+
+package errors
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	l := NewSlogLogger(slog.New(handler))
+
+	l.Log(LevelWarn, "Too many requests", Fields{"status": 429})
+	Contains(t, buf.String(), `"level":"WARN"`)
+	Contains(t, buf.String(), `"msg":"Too many requests"`)
+	Contains(t, buf.String(), `"status":429`)
+
+	buf.Reset()
+	l.Log(LevelDebug, "debug", nil)
+	Contains(t, buf.String(), `"level":"DEBUG"`)
+}
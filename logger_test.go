@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cer "github.com/go-openapi/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestLevel_String(t *testing.T) {
+	Equal(t, "debug", LevelDebug.String())
+	Equal(t, "info", LevelInfo.String())
+	Equal(t, "warn", LevelWarn.String())
+	Equal(t, "error", LevelError.String())
+	Equal(t, "none", LevelNone.String())
+	Equal(t, "unknown", Level(42).String())
+}
+
+func TestNewLogrusLogger(t *testing.T) {
+	logger, logs := test.NewNullLogger()
+	logger.SetLevel(log.DebugLevel)
+	l := NewLogrusLogger(logger.WithField("util", "errors"))
+
+	l.Log(LevelDebug, "debug", nil)
+	l.Log(LevelInfo, "info", nil)
+	l.Log(LevelWarn, "warn", nil)
+	l.Log(LevelError, "error", Fields{"status": 500})
+
+	entries := logs.AllEntries()
+	Len(t, entries, 4)
+	Equal(t, log.DebugLevel, entries[0].Level)
+	Equal(t, log.InfoLevel, entries[1].Level)
+	Equal(t, log.WarnLevel, entries[2].Level)
+	Equal(t, log.ErrorLevel, entries[3].Level)
+	Equal(t, "error", entries[3].Message)
+	Equal(t, log.Fields{"util": "errors", "status": 500}, entries[3].Data)
+}
+
+func TestNopLogger(t *testing.T) {
+	var logged bool
+	logger := LoggerFunc(func(Level, string, Fields) { logged = true })
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	NewServer(WithLogger(logger)).ServeError(httptest.NewRecorder(), r, errors.New("boom"))
+	True(t, logged)
+
+	NotPanics(t, func() {
+		NewServer(WithLogger(NopLogger)).ServeError(httptest.NewRecorder(), r, errors.New("boom"))
+	})
+}
+
+func TestLevel_UnmarshalText(t *testing.T) {
+	var l Level
+	NoError(t, l.UnmarshalText([]byte("warn")))
+	Equal(t, LevelWarn, l)
+
+	text, err := l.MarshalText()
+	NoError(t, err)
+	Equal(t, "warn", string(text))
+
+	Error(t, l.UnmarshalText([]byte("fatal")))
+}
+
+func TestLevelForStatus(t *testing.T) {
+	Equal(t, LevelInfo, LevelForStatus(http.StatusNotFound))
+	Equal(t, LevelWarn, LevelForStatus(http.StatusTooManyRequests))
+	Equal(t, LevelWarn, LevelForStatus(http.StatusServiceUnavailable))
+	Equal(t, LevelError, LevelForStatus(http.StatusInternalServerError))
+	Equal(t, LevelError, LevelForStatus(http.StatusGatewayTimeout))
+	Equal(t, LevelDebug, LevelForStatus(http.StatusOK))
+}
+
+func TestServer_log(t *testing.T) {
+	logger, logs := test.NewNullLogger()
+	logger.SetLevel(log.DebugLevel)
+
+	catalog := NewCatalog(DefaultCatalog.Entries()...)
+	catalog.Register(Entry{Title: ResourceNotFound, Status: http.StatusNotFound, Code: notFound, LogLevel: LevelDebug})
+	s := NewServer(WithLogger(NewLogrusLogger(logger)), WithCatalog(catalog))
+
+	r := httptest.NewRequest(http.MethodPost, "/listings?limit=a", nil)
+	s.ServeError(httptest.NewRecorder(), r, cer.CompositeValidationError(cer.InvalidType("limit", "query", "integer", "a")))
+
+	entry := logs.LastEntry()
+	Equal(t, log.InfoLevel, entry.Level)
+	Equal(t, InvalidQueryParam, entry.Message)
+	Equal(t, log.Fields{
+		"id":            "param.invalid_query",
+		"status":        int32(http.StatusBadRequest),
+		"title":         InvalidQueryParam,
+		"code":          badRequest,
+		"instance":      InstClient,
+		"invalidParams": 1,
+		"method":        http.MethodPost,
+		"path":          "/listings",
+		"cause":         "validation failure list:\nlimit in query must be of type integer: \"a\"",
+	}, entry.Data)
+
+	s.ServeError(httptest.NewRecorder(), r, cer.New(http.StatusServiceUnavailable, "overloaded"))
+	Equal(t, log.ErrorLevel, logs.LastEntry().Level)
+
+	s.ServeError(httptest.NewRecorder(), r, cer.NotFound("listing"))
+	Equal(t, log.DebugLevel, logs.LastEntry().Level)
+}
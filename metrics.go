@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+
+	"github.com/Kviky/errors/models"
+)
+
+// Metrics is a Hook counting served problems by status, code and problem
+// in expvar maps
+type Metrics struct {
+	root    *expvar.Map
+	status  *expvar.Map
+	code    *expvar.Map
+	problem *expvar.Map
+}
+
+// NewMetrics creates Metrics published under given expvar name, so the counters
+// are available at /debug/vars as
+//
+//	{"problems": {"status": {"404": 3}, "code": {"Not Found": 3}, "problem": {"listing.not_found": 3}}}
+//
+// Problems are counted by ID, which doesn't change with localization, or by title
+// when they have no ID.
+// An empty name creates unpublished metrics. Like expvar.Publish it panics
+// when the name is already registered.
+func NewMetrics(name string) *Metrics {
+	m := &Metrics{
+		root:    new(expvar.Map).Init(),
+		status:  new(expvar.Map).Init(),
+		code:    new(expvar.Map).Init(),
+		problem: new(expvar.Map).Init(),
+	}
+	m.root.Set("status", m.status)
+	m.root.Set("code", m.code)
+	m.root.Set("problem", m.problem)
+
+	if name != "" {
+		expvar.Publish(name, m.root)
+	}
+	return m
+}
+
+// OnProblem implements Hook
+func (m *Metrics) OnProblem(_ *http.Request, problem *models.ProblemDetails, _ error) {
+	m.status.Add(strconv.Itoa(int(problem.Status)), 1)
+	m.code.Add(problem.Code, 1)
+	key := problemID(problem)
+	if key == "" {
+		key = problem.Title
+	}
+	m.problem.Add(key, 1)
+}
+
+// Var returns the expvar map holding all counters
+func (m *Metrics) Var() *expvar.Map {
+	return m.root
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cer "github.com/go-openapi/errors"
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestMetrics_OnProblem(t *testing.T) {
+	metrics := NewMetrics("")
+	s := NewServer(WithLogger(NopLogger), WithHooks(metrics))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	s.ServeError(httptest.NewRecorder(), r, errors.New("boom"))
+	s.ServeError(httptest.NewRecorder(), r, errors.New("boom"))
+	s.ServeError(httptest.NewRecorder(), r, cer.NotFound("listing"))
+
+	vars := metrics.Var()
+	Equal(t, "2", vars.Get("status").(*expvar.Map).Get("500").String())
+	Equal(t, "1", vars.Get("status").(*expvar.Map).Get("404").String())
+	Equal(t, "2", vars.Get("code").(*expvar.Map).Get(internalServerError).String())
+	Equal(t, "2", vars.Get("problem").(*expvar.Map).Get("system.failure").String())
+	Equal(t, "1", vars.Get("problem").(*expvar.Map).Get("resource.not_found").String())
+}
+
+func TestMetrics_localized(t *testing.T) {
+	metrics := NewMetrics("")
+	localizer := LocalizerFunc(func(r *http.Request, problem *models.ProblemDetails) {
+		if r.Header.Get("Accept-Language") == "hr" && problem.Title == ResourceNotFound {
+			problem.Title = "Resurs nije pronađen!"
+		}
+	})
+	s := NewServer(WithLogger(NopLogger), WithLocalizer(localizer), WithHooks(metrics))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	s.ServeError(httptest.NewRecorder(), r, cer.NotFound("listing"))
+	r.Header.Set("Accept-Language", "hr")
+	s.ServeError(httptest.NewRecorder(), r, cer.NotFound("listing"))
+	s.ServeError(httptest.NewRecorder(), r, &Problem{ProblemDetails: &models.ProblemDetails{Title: "Boat sunk!", Status: 410}})
+
+	problems := metrics.Var().Get("problem").(*expvar.Map)
+	Equal(t, "2", problems.Get("resource.not_found").String())
+	Equal(t, "1", problems.Get("Boat sunk!").String())
+	Nil(t, problems.Get("Resurs nije pronađen!"))
+}
+
+func TestNewMetrics(t *testing.T) {
+	metrics := NewMetrics("test_problems")
+	Equal(t, metrics.Var(), expvar.Get("test_problems"))
+
+	Panics(t, func() { NewMetrics("test_problems") })
+}
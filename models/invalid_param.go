@@ -17,6 +17,9 @@ import (
 // swagger:model invalidParam
 type InvalidParam struct {
 
+	// Location of the param, e.g. body, query, path or header
+	In string `json:"in,omitempty"`
+
 	// param
 	// Required: true
 	Param *string `json:"param"`
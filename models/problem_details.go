@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/go-openapi/errors"
@@ -40,6 +41,138 @@ type ProblemDetails struct {
 
 	// URI of the resource
 	Type string `json:"type,omitempty"`
+
+	// problem details additional properties
+	ProblemDetailsAdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON unmarshals this object with additional properties from JSON
+func (m *ProblemDetails) UnmarshalJSON(data []byte) error {
+	// stage 1, bind the properties
+	var stage1 struct {
+
+		// Human readable HTTP code explanation
+		Code string `json:"code,omitempty"`
+
+		// Human readable description/detail of error
+		Detail string `json:"detail,omitempty"`
+
+		// Instance where error occured
+		Instance string `json:"instance,omitempty"`
+
+		// invalid params
+		// Min Items: 1
+		InvalidParams []*InvalidParam `json:"invalidParams,omitempty"`
+
+		// HTTP status code
+		Status int32 `json:"status,omitempty"`
+
+		// Human readable title of error
+		Title string `json:"title,omitempty"`
+
+		// URI of the resource
+		Type string `json:"type,omitempty"`
+	}
+	if err := json.Unmarshal(data, &stage1); err != nil {
+		return err
+	}
+	var rcv ProblemDetails
+
+	rcv.Code = stage1.Code
+	rcv.Detail = stage1.Detail
+	rcv.Instance = stage1.Instance
+	rcv.InvalidParams = stage1.InvalidParams
+	rcv.Status = stage1.Status
+	rcv.Title = stage1.Title
+	rcv.Type = stage1.Type
+	*m = rcv
+
+	// stage 2, remove properties and add to map
+	stage2 := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &stage2); err != nil {
+		return err
+	}
+
+	delete(stage2, "code")
+	delete(stage2, "detail")
+	delete(stage2, "instance")
+	delete(stage2, "invalidParams")
+	delete(stage2, "status")
+	delete(stage2, "title")
+	delete(stage2, "type")
+	// stage 3, add additional properties values
+	if len(stage2) > 0 {
+		result := make(map[string]interface{})
+		for k, v := range stage2 {
+			var toadd interface{}
+			if err := json.Unmarshal(v, &toadd); err != nil {
+				return err
+			}
+			result[k] = toadd
+		}
+		m.ProblemDetailsAdditionalProperties = result
+	}
+
+	return nil
+}
+
+// MarshalJSON marshals this object with additional properties into a JSON object
+func (m ProblemDetails) MarshalJSON() ([]byte, error) {
+	var stage1 struct {
+
+		// Human readable HTTP code explanation
+		Code string `json:"code,omitempty"`
+
+		// Human readable description/detail of error
+		Detail string `json:"detail,omitempty"`
+
+		// Instance where error occured
+		Instance string `json:"instance,omitempty"`
+
+		// invalid params
+		// Min Items: 1
+		InvalidParams []*InvalidParam `json:"invalidParams,omitempty"`
+
+		// HTTP status code
+		Status int32 `json:"status,omitempty"`
+
+		// Human readable title of error
+		Title string `json:"title,omitempty"`
+
+		// URI of the resource
+		Type string `json:"type,omitempty"`
+	}
+
+	stage1.Code = m.Code
+	stage1.Detail = m.Detail
+	stage1.Instance = m.Instance
+	stage1.InvalidParams = m.InvalidParams
+	stage1.Status = m.Status
+	stage1.Title = m.Title
+	stage1.Type = m.Type
+
+	// make JSON object for known properties
+	props, err := json.Marshal(stage1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.ProblemDetailsAdditionalProperties) == 0 { // no additional properties
+		return props, nil
+	}
+
+	// make JSON object for the additional properties
+	additional, err := json.Marshal(m.ProblemDetailsAdditionalProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(props) < 3 { // "{}": only additional properties
+		return additional, nil
+	}
+
+	// concatenate the 2 objects
+	return swag.ConcatJSON(props, additional), nil
 }
 
 // Validate validates this problem details
@@ -92,3 +92,27 @@ func TestProblemDetails_validateInvalidParams(t *testing.T) {
 
 	EqualError(t, err, errv.Error())
 }
+
+func TestProblemDetails_AdditionalProperties(t *testing.T) {
+	details := ProblemDetails{
+		Status: http.StatusInternalServerError,
+		ProblemDetailsAdditionalProperties: map[string]interface{}{
+			"support": "info@kviky.com",
+		},
+	}
+
+	binary, err := details.MarshalBinary()
+	NoError(t, err)
+	Equal(t, `{"status":500,"support":"info@kviky.com"}`, string(binary))
+
+	details = ProblemDetails{}
+	err = details.UnmarshalBinary(binary)
+	NoError(t, err)
+	EqualValues(t, http.StatusInternalServerError, details.Status)
+	Equal(t, map[string]interface{}{"support": "info@kviky.com"}, details.ProblemDetailsAdditionalProperties)
+
+	details = ProblemDetails{ProblemDetailsAdditionalProperties: map[string]interface{}{"support": "info@kviky.com"}}
+	binary, err = details.MarshalBinary()
+	NoError(t, err)
+	Equal(t, `{"support":"info@kviky.com"}`, string(binary))
+}
@@ -0,0 +1,137 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SpecFormat is the version of generated API definitions
+type SpecFormat int
+
+const (
+	// Swagger2 generates definitions and responses of a Swagger 2.0 spec
+	Swagger2 SpecFormat = iota
+	// OpenAPI3 generates components of an OpenAPI 3 spec
+	OpenAPI3
+)
+
+// Spec generates the ProblemDetails schema and a response for every entry of
+// the catalog with an example rendered as ServeError serves it. Swagger 2.0
+// responses are referenced as #/responses/ListingNotFound, OpenAPI 3 ones as
+// #/components/responses/ListingNotFound. The result is meant to be marshaled
+// as YAML or JSON and merged into a spec.
+func (c *Catalog) Spec(format SpecFormat, contentType string) map[string]interface{} {
+	schemaRef := "#/definitions/"
+	if format == OpenAPI3 {
+		schemaRef = "#/components/schemas/"
+	}
+
+	responses := make(map[string]interface{})
+	for _, e := range c.Entries() {
+		response := map[string]interface{}{
+			"description": e.Title,
+		}
+		schema := map[string]interface{}{"$ref": schemaRef + "ProblemDetails"}
+		example := c.example(e.Title)
+
+		if format == OpenAPI3 {
+			response["content"] = map[string]interface{}{
+				contentType: map[string]interface{}{
+					"schema":  schema,
+					"example": example,
+				},
+			}
+		} else {
+			response["schema"] = schema
+			response["examples"] = map[string]interface{}{contentType: example}
+		}
+		responses[specName(e)] = response
+	}
+
+	schemas := problemSchemas(schemaRef)
+	if format == OpenAPI3 {
+		return map[string]interface{}{
+			"components": map[string]interface{}{
+				"schemas":   schemas,
+				"responses": responses,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"definitions": schemas,
+		"responses":   responses,
+	}
+}
+
+// example renders the problem of given title as a generic JSON value
+func (c *Catalog) example(title string) interface{} {
+	problem := c.Problem(title)
+	DefaultSupport().Apply(problem)
+
+	data, _ := problem.MarshalBinary()
+	var example map[string]interface{}
+	_ = json.Unmarshal(data, &example)
+	return example
+}
+
+// specName returns the name of the entry's response, the title in
+// CamelCase for entries without Name
+func specName(e Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	var b strings.Builder
+	for _, word := range wordPattern.FindAllString(e.Title, -1) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// problemSchemas returns the schemas of models.ProblemDetails and models.InvalidParam
+// as defined in models/models.yml with the id extension member
+func problemSchemas(ref string) map[string]interface{} {
+	str := func(description string) map[string]interface{} {
+		s := map[string]interface{}{"type": "string"}
+		if description != "" {
+			s["description"] = description
+		}
+		return s
+	}
+
+	return map[string]interface{}{
+		"ProblemDetails": map[string]interface{}{
+			"title":                "ProblemDetails",
+			"type":                 "object",
+			"additionalProperties": true,
+			"properties": map[string]interface{}{
+				"id":    str("Stable identifier of the problem, e.g. listing.not_found"),
+				"type":  str("URI of the resource"),
+				"title": str("Human readable title of error"),
+				"status": map[string]interface{}{
+					"description": "HTTP status code",
+					"type":        "integer",
+					"format":      "int32",
+				},
+				"detail":   str("Human readable description/detail of error"),
+				"instance": str("Instance where error occured"),
+				"code":     str("Human readable HTTP code explanation"),
+				"invalidParams": map[string]interface{}{
+					"x-omitempty": true,
+					"type":        "array",
+					"items":       map[string]interface{}{"$ref": ref + "InvalidParam"},
+					"minItems":    1,
+				},
+			},
+		},
+		"InvalidParam": map[string]interface{}{
+			"title": "InvalidParam",
+			"type":  "object",
+			"properties": map[string]interface{}{
+				"param":  str(""),
+				"in":     str("Location of the param, e.g. body, query, path or header"),
+				"reason": str(""),
+			},
+			"required": []string{"param"},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+// lookup walks nested maps of a generated spec
+func lookup(t *testing.T, spec interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		m, ok := spec.(map[string]interface{})
+		if !True(t, ok, "no map at %s", key) {
+			return nil
+		}
+		spec = m[key]
+	}
+	return spec
+}
+
+func TestCatalog_Spec_swagger(t *testing.T) {
+	spec := DefaultCatalog.Spec(Swagger2, "application/json")
+
+	responses := lookup(t, spec, "responses").(map[string]interface{})
+	Len(t, responses, len(DefaultCatalog.Entries()))
+	Equal(t, "#/definitions/ProblemDetails", lookup(t, spec, "responses", "ListingNotFound", "schema", "$ref"))
+	Equal(t, "#/definitions/InvalidParam", lookup(t, spec, "definitions", "ProblemDetails", "properties", "invalidParams", "items", "$ref"))
+
+	example := lookup(t, spec, "responses", "ListingNotFound", "examples", "application/json")
+	EqualValues(t, 404, lookup(t, example, "status"))
+	Equal(t, ListingNotFound, lookup(t, example, "title"))
+	Equal(t, notFound, lookup(t, example, "code"))
+
+	example = lookup(t, spec, "responses", "SystemFailure", "examples", "application/json")
+	NotNil(t, lookup(t, example, ExtSupport))
+
+	_, err := json.Marshal(spec)
+	NoError(t, err)
+}
+
+func TestCatalog_Spec_openAPI(t *testing.T) {
+	spec := DefaultCatalog.Spec(OpenAPI3, "application/problem+json")
+
+	response := lookup(t, spec, "components", "responses", "ListingNotFound")
+	Equal(t, ListingNotFound, lookup(t, response, "description"))
+	Equal(t, "#/components/schemas/ProblemDetails", lookup(t, response, "content", "application/problem+json", "schema", "$ref"))
+	EqualValues(t, 404, lookup(t, response, "content", "application/problem+json", "example", "status"))
+	NotNil(t, lookup(t, spec, "components", "schemas", "InvalidParam"))
+}
+
+func Test_specName(t *testing.T) {
+	Equal(t, "ListingNotFound", specName(Entry{Name: "ListingNotFound", Title: "Whatever"}))
+	Equal(t, "ListingIsArchived", specName(Entry{Title: "Listing is archived!"}))
+}
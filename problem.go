@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Kviky/errors/models"
+)
+
+// Problem is an error carrying ProblemDetails. It is served by ServeError as is.
+type Problem struct {
+	*models.ProblemDetails
+	// Header is added to the response headers when the problem is served
+	Header http.Header
+	cause  error
+}
+
+// NewProblem creates a Problem error for given title of the DefaultCatalog
+func NewProblem(title string) *Problem {
+	return Wrap(nil, title)
+}
+
+// NewProblemf creates a Problem error for given title of the DefaultCatalog
+// with the fmt verbs of its detail filled with args. Details without verbs are kept.
+//
+//	errors.NewProblemf("Boat not found!", boatID)
+func NewProblemf(title string, args ...interface{}) *Problem {
+	p := Wrap(nil, title)
+	if len(args) > 0 && verbPattern.MatchString(p.Detail) {
+		p.Detail = fmt.Sprintf(p.Detail, args...)
+	}
+	return p
+}
+
+// Wrap creates a Problem error for given title of the DefaultCatalog caused by err.
+// Support information and localization are applied by the Server serving it.
+func Wrap(err error, title string) *Problem {
+	return &Problem{ProblemDetails: DefaultCatalog.Problem(title), cause: err}
+}
+
+// Error implements the error interface
+func (p *Problem) Error() string {
+	if p == nil || p.ProblemDetails == nil {
+		return "<nil>"
+	}
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + " " + p.Detail
+}
+
+// Unwrap returns the error which caused the problem
+func (p *Problem) Unwrap() error {
+	if p == nil {
+		return nil
+	}
+	return p.cause
+}
+
+// ID returns the stable identifier of the problem
+func (p *Problem) ID() string {
+	if p == nil {
+		return ""
+	}
+	return problemID(p.ProblemDetails)
+}
+
+// Is reports whether target is a Problem with the same ID, or the same
+// title when any of them has no ID
+func (p *Problem) Is(target error) bool {
+	t, ok := target.(*Problem)
+	if p == nil || !ok || t == nil || t.ProblemDetails == nil || p.ProblemDetails == nil {
+		return false
+	}
+	if id := p.ID(); id != "" && t.ID() != "" {
+		return id == t.ID()
+	}
+	return t.Title == p.Title
+}
+
+// Is reports whether any error in err's chain is a Problem with given ID or title.
+// IDs keep matching when the title is reworded or localized.
+//
+//	if errors.Is(err, errors.ListingNotFound) {
+//	if errors.Is(err, "listing.not_found") {
+func Is(err error, key string) bool {
+	for err != nil {
+		var p *Problem
+		if !errors.As(err, &p) {
+			return false
+		}
+		if p.ProblemDetails != nil && (p.Title == key || p.ID() == key) {
+			return true
+		}
+		err = p.Unwrap()
+	}
+	return false
+}
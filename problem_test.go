@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestNewProblem(t *testing.T) {
+	p := NewProblem(ListingNotFound)
+	EqualValues(t, http.StatusNotFound, p.Status)
+	Equal(t, "Listing not found! The listing indicated in the request does not exist!", p.Error())
+
+	var nilProblem *Problem
+	Equal(t, "<nil>", nilProblem.Error())
+}
+
+func TestProblem_Is(t *testing.T) {
+	err := fmt.Errorf("get listing: %w", NewProblem(ListingNotFound))
+
+	True(t, errors.Is(err, NewProblem(ListingNotFound)))
+	False(t, errors.Is(err, NewProblem(UserNotFound)))
+
+	True(t, Is(err, ListingNotFound))
+	False(t, Is(err, UserNotFound))
+	False(t, Is(errors.New(ListingNotFound), ListingNotFound))
+	False(t, Is(nil, ListingNotFound))
+
+	wrapped := Wrap(NewProblem(ListingNotFound), ServiceUnavailable)
+	True(t, Is(wrapped, ServiceUnavailable))
+	True(t, Is(wrapped, ListingNotFound))
+}
+
+func TestNewProblemf(t *testing.T) {
+	defer func(c *Catalog) { DefaultCatalog = c }(DefaultCatalog)
+	DefaultCatalog = NewCatalog(DefaultEntries()...)
+	DefaultCatalog.Register(Entry{ID: "boat.not_found", Title: "Boat not found!", Detail: "Boat %v doesn't exist!", Status: 404, Code: notFound})
+
+	p := NewProblemf("Boat not found!", 42)
+	Equal(t, "Boat 42 doesn't exist!", p.Detail)
+	Equal(t, "boat.not_found", p.ID())
+
+	p = NewProblemf("Boat not found!")
+	Equal(t, "Boat %v doesn't exist!", p.Detail)
+
+	p = NewProblemf(ListingNotFound, 42)
+	Equal(t, "The listing indicated in the request does not exist!", p.Detail)
+
+	p = NewProblemf(InactiveListing, 42)
+	Equal(t, "Listing 42 is not in the active state!", p.Detail)
+}
+
+func TestServeError_Problem(t *testing.T) {
+	p := NewProblem(InactiveListing)
+	p.Detail = fmt.Sprintf(p.Detail, 42)
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/offers", nil)
+	ServeError(rr, r, fmt.Errorf("create offer: %w", p))
+	EqualValues(t, http.StatusBadRequest, rr.Code)
+
+	details := &models.ProblemDetails{}
+	NoError(t, details.UnmarshalBinary(rr.Body.Bytes()))
+	Equal(t, "Listing 42 is not in the active state!", details.Detail)
+	Equal(t, "/offers", details.Type)
+	Equal(t, "/", p.Type)
+
+	rr = httptest.NewRecorder()
+	ServeError(rr, r, (*Problem)(nil))
+	EqualValues(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestServeError_ProblemCopy(t *testing.T) {
+	p := NewValidator(MandatoryParamIncorrect)
+	p.Add("name", "Param missing")
+	err := p.Err()
+
+	s := NewServer(WithLogger(NopLogger), WithHooks(HookFunc(func(_ *http.Request, problem *models.ProblemDetails, _ error) {
+		problem.ProblemDetailsAdditionalProperties["traceId"] = "abc"
+		problem.InvalidParams[0].Reason = "Changed"
+		problem.InvalidParams = append(problem.InvalidParams[:0], problem.InvalidParams[0])
+	})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.ServeError(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/boats", nil), err)
+		}()
+	}
+	wg.Wait()
+
+	var served *Problem
+	True(t, errors.As(err, &served))
+	NotContains(t, served.ProblemDetailsAdditionalProperties, "traceId")
+	Equal(t, "Param missing", served.InvalidParams[0].Reason)
+	Equal(t, "/", served.Type)
+}
+
+func TestProblem_Is_nil(t *testing.T) {
+	False(t, errors.Is((*Problem)(nil), NewProblem(ListingNotFound)))
+	False(t, errors.Is(NewProblem(ListingNotFound), (*Problem)(nil)))
+}
+
+func TestIs_id(t *testing.T) {
+	p := NewProblem(ListingNotFound)
+	Equal(t, "listing.not_found", p.ID())
+	True(t, Is(p, "listing.not_found"))
+
+	// a localized problem keeps matching by ID
+	localized := NewProblem(ListingNotFound)
+	localized.Title = "Oglas nije pronađen!"
+	True(t, errors.Is(localized, p))
+	True(t, Is(fmt.Errorf("get listing: %w", localized), "listing.not_found"))
+	False(t, errors.Is(localized, NewProblem(UserNotFound)))
+	Empty(t, (*Problem)(nil).ID())
+}
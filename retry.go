@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	cer "github.com/go-openapi/errors"
+)
+
+// Retry describes whether a failed request may be retried
+type Retry struct {
+	// Retryable reports that the failure is transient
+	Retryable bool `json:"retryable" yaml:"retryable"`
+	// IdempotentOnly reports that only idempotent requests may be retried,
+	// because the request might have been processed
+	IdempotentOnly bool `json:"idempotentOnly,omitempty" yaml:"idempotentOnly,omitempty"`
+	// Backoff is the suggested delay before the next attempt
+	Backoff time.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// RetryForStatus returns the default retry metadata of a problem with given status.
+// 429 and 503 are retryable, 504 only for idempotent requests, everything else is not.
+func RetryForStatus(status int32) Retry {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return Retry{Retryable: true, Backoff: time.Second}
+	case http.StatusGatewayTimeout:
+		return Retry{Retryable: true, IdempotentOnly: true, Backoff: time.Second}
+	default:
+		return Retry{}
+	}
+}
+
+// RetryOf returns retry metadata of the first Problem or go-openapi error in err's
+// chain using the DefaultCatalog. It reports false for other errors.
+func RetryOf(err error) (Retry, bool) {
+	return DefaultCatalog.RetryOf(err)
+}
+
+// RetryOf returns retry metadata of the first Problem or go-openapi error in err's
+// chain. Problems are looked up in the catalog by ID or title, so decoded remote problems
+// get the metadata of the matching entry. It reports false for other errors.
+func (c *Catalog) RetryOf(err error) (Retry, bool) {
+	var p *Problem
+	if errors.As(err, &p) && p.ProblemDetails != nil {
+		if e, ok := c.lookupProblem(p.ProblemDetails); ok {
+			return e.RetryPolicy(), true
+		}
+		return RetryForStatus(p.Status), true
+	}
+
+	var ce cer.Error
+	if errors.As(err, &ce) {
+		return RetryForStatus(ce.Code()), true
+	}
+	return Retry{}, false
+}
+
+// IsRetryable reports whether the problem is retryable. Retryable problems with
+// IdempotentOnly, e.g. 504, may be retried only by idempotent requests, so callers
+// of other methods check it in RetryOf.
+func IsRetryable(err error) bool {
+	r, ok := RetryOf(err)
+	return ok && r.Retryable
+}
+
+// IsTemporary reports whether the failure is transient. Besides retryable problems
+// it recognises errors with Temporary or Timeout method returning true, e.g. net.Error.
+func IsTemporary(err error) bool {
+	if r, ok := RetryOf(err); ok {
+		return r.Retryable
+	}
+
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
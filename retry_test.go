@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	cer "github.com/go-openapi/errors"
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestRetryForStatus(t *testing.T) {
+	Equal(t, Retry{Retryable: true, Backoff: time.Second}, RetryForStatus(http.StatusTooManyRequests))
+	Equal(t, Retry{Retryable: true, Backoff: time.Second}, RetryForStatus(http.StatusServiceUnavailable))
+	Equal(t, Retry{Retryable: true, IdempotentOnly: true, Backoff: time.Second}, RetryForStatus(http.StatusGatewayTimeout))
+	Equal(t, Retry{}, RetryForStatus(http.StatusInternalServerError))
+	Equal(t, Retry{}, RetryForStatus(http.StatusNotFound))
+}
+
+func TestCatalog_RetryOf(t *testing.T) {
+	catalog := NewCatalog(DefaultCatalog.Entries()...)
+	catalog.Register(Entry{
+		Title:  "Reservation locked!",
+		Status: http.StatusConflict,
+		Retry:  &Retry{Retryable: true, Backoff: 5 * time.Second},
+	})
+
+	retry, ok := catalog.RetryOf(&Problem{ProblemDetails: &models.ProblemDetails{Title: "Reservation locked!", Status: http.StatusConflict}})
+	True(t, ok)
+	Equal(t, 5*time.Second, retry.Backoff)
+
+	retry, ok = catalog.RetryOf(fmt.Errorf("reserve: %w", NewProblem(CongestionRisk)))
+	True(t, ok)
+	True(t, retry.Retryable)
+
+	retry, ok = catalog.RetryOf(&Problem{ProblemDetails: &models.ProblemDetails{Title: "Unknown!", Status: http.StatusServiceUnavailable}})
+	True(t, ok)
+	True(t, retry.Retryable)
+
+	retry, ok = catalog.RetryOf(cer.New(http.StatusGatewayTimeout, "timeout"))
+	True(t, ok)
+	True(t, retry.IdempotentOnly)
+
+	_, ok = catalog.RetryOf(errors.New("boom"))
+	False(t, ok)
+}
+
+func TestIsRetryable(t *testing.T) {
+	True(t, IsRetryable(NewProblem(ServiceUnavailable)))
+	True(t, IsRetryable(NewProblem(CongestionRisk)))
+	True(t, IsRetryable(NewProblem(GatewayTimeout)))
+	False(t, IsRetryable(NewProblem(ListingNotFound)))
+	False(t, IsRetryable(NewProblem(SystemFailure)))
+	False(t, IsRetryable(errors.New("boom")))
+	False(t, IsRetryable(nil))
+}
+
+func TestIsTemporary(t *testing.T) {
+	True(t, IsTemporary(NewProblem(GatewayTimeout)))
+	True(t, IsTemporary(cer.New(http.StatusServiceUnavailable, "overloaded")))
+	False(t, IsTemporary(NewProblem(ListingNotFound)))
+	False(t, IsTemporary(errors.New("boom")))
+
+	True(t, IsTemporary(&net.DNSError{IsTimeout: true}))
+	True(t, IsTemporary(fmt.Errorf("dial: %w", &net.DNSError{IsTemporary: true})))
+	False(t, IsTemporary(&net.DNSError{}))
+	True(t, IsTemporary(context.DeadlineExceeded))
+}
@@ -0,0 +1,241 @@
+package errors
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Kviky/errors/models"
+)
+
+// Exposure controls whether messages of the served errors are exposed to clients
+type Exposure int
+
+const (
+	// ExposeErrors appends the error message to the detail of 400 and 404 problems
+	ExposeErrors Exposure = iota
+	// HideErrors keeps the catalog detail only
+	HideErrors
+)
+
+// Aggregation controls how validation errors of a CompositeError are served
+type Aggregation int
+
+const (
+	// AggregateFirst serves only the problem with the highest priority
+	AggregateFirst Aggregation = iota
+	// AggregateAll serves the problem with the highest priority together
+	// with invalid params of all other problems
+	AggregateAll
+)
+
+// Localizer translates problems before they are served. Support information is
+// applied first, so the detail of 5xx problems already ends with the support text,
+// which is also available in the support extension member.
+type Localizer interface {
+	Localize(r *http.Request, problem *models.ProblemDetails)
+}
+
+// LocalizerFunc is an adapter to use ordinary functions as Localizer
+type LocalizerFunc func(r *http.Request, problem *models.ProblemDetails)
+
+// Localize calls f(r, problem)
+func (f LocalizerFunc) Localize(r *http.Request, problem *models.ProblemDetails) {
+	f(r, problem)
+}
+
+// Hook is notified about every problem served by a Server
+type Hook interface {
+	OnProblem(r *http.Request, problem *models.ProblemDetails, err error)
+}
+
+// HookFunc is an adapter to use ordinary functions as Hook
+type HookFunc func(r *http.Request, problem *models.ProblemDetails, err error)
+
+// OnProblem calls f(r, problem, err)
+func (f HookFunc) OnProblem(r *http.Request, problem *models.ProblemDetails, err error) {
+	f(r, problem, err)
+}
+
+// Server serves errors as ProblemDetails responses
+type Server struct {
+	logger      Logger
+	catalog     *Catalog
+	support     *Support
+	contentType string
+	exposure    Exposure
+	localizer   Localizer
+	aggregation Aggregation
+	hooks       []Hook
+	typeBase    string
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithLogger sets the logger of served problems. Use NopLogger to disable logging.
+func WithLogger(logger Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithCatalog sets the catalog used to create problems
+func WithCatalog(catalog *Catalog) Option {
+	return func(s *Server) {
+		s.catalog = catalog
+	}
+}
+
+// WithSupport sets the support information attached to 5xx problems
+// instead of the package-level one
+func WithSupport(support Support) Option {
+	return func(s *Server) {
+		s.support = &support
+	}
+}
+
+// WithContentType sets the Content-Type header of served problems
+func WithContentType(contentType string) Option {
+	return func(s *Server) {
+		s.contentType = contentType
+	}
+}
+
+// WithExposure sets whether error messages are exposed to clients
+func WithExposure(exposure Exposure) Option {
+	return func(s *Server) {
+		s.exposure = exposure
+	}
+}
+
+// WithLocalizer sets the localizer applied to every served problem
+func WithLocalizer(localizer Localizer) Option {
+	return func(s *Server) {
+		s.localizer = localizer
+	}
+}
+
+// WithAggregation sets how validation errors are served
+func WithAggregation(aggregation Aggregation) Option {
+	return func(s *Server) {
+		s.aggregation = aggregation
+	}
+}
+
+// WithHooks adds hooks notified about every served problem
+func WithHooks(hooks ...Hook) Option {
+	return func(s *Server) {
+		s.hooks = append(s.hooks, hooks...)
+	}
+}
+
+// WithTypeBase sets the type of problems with ID to base followed by the ID,
+// e.g. https://api.kviky.com/problems/ serves type https://api.kviky.com/problems/listing.not_found.
+// Without it the type is the request URI.
+func WithTypeBase(base string) Option {
+	return func(s *Server) {
+		s.typeBase = base
+	}
+}
+
+// NewServer creates a Server. Without options it behaves as the package-level ServeError.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		logger:      NewLogrusLogger(log.WithField("util", "errors")),
+		catalog:     DefaultCatalog,
+		contentType: "application/json",
+		exposure:    ExposeErrors,
+		aggregation: AggregateFirst,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DefaultServer is used by the package-level ServeError
+var DefaultServer = NewServer()
+
+// problem creates localized ProblemDetails for given title
+func (s *Server) problem(r *http.Request, title string) *models.ProblemDetails {
+	problem := s.catalog.Problem(title)
+	problem.Type = s.problemType(r, problem)
+	s.localize(r, problem)
+	return problem
+}
+
+// localize applies the support information and then the localizer to the problem
+func (s *Server) localize(r *http.Request, problem *models.ProblemDetails) {
+	if s.support != nil {
+		s.support.Apply(problem)
+	} else {
+		DefaultSupport().Apply(problem)
+	}
+
+	if s.localizer != nil {
+		s.localizer.Localize(r, problem)
+	}
+}
+
+// log logs the served problem at the level of its catalog entry
+func (s *Server) log(r *http.Request, problem *models.ProblemDetails, err error) {
+	level := LevelForStatus(problem.Status)
+	if e, ok := s.catalog.lookupProblem(problem); ok {
+		level = e.Level()
+	}
+	if level == LevelNone {
+		return
+	}
+
+	fields := Fields{
+		"id":            problemID(problem),
+		"status":        problem.Status,
+		"title":         problem.Title,
+		"code":          problem.Code,
+		"instance":      problem.Instance,
+		"invalidParams": len(problem.InvalidParams),
+	}
+	if r != nil {
+		fields["method"] = r.Method
+		if r.URL != nil {
+			fields["path"] = r.URL.Path
+		}
+	}
+	if err != nil {
+		fields["cause"] = err.Error()
+	}
+
+	s.logger.Log(level, problem.Title, fields)
+}
+
+// write logs the problem, notifies hooks and writes the problem
+func (s *Server) write(rw http.ResponseWriter, r *http.Request, problem *models.ProblemDetails, err error) {
+	s.log(r, problem, err)
+	for _, hook := range s.hooks {
+		hook.OnProblem(r, problem, err)
+	}
+
+	// HEAD responses carry status and headers without a body
+	if r != nil && r.Method == http.MethodHead {
+		rw.WriteHeader(int(problem.Status))
+		return
+	}
+	writeResponse(problem, rw)
+}
+
+// problemType returns the type URI of the problem
+func (s *Server) problemType(r *http.Request, problem *models.ProblemDetails) string {
+	if id := problemID(problem); s.typeBase != "" && id != "" {
+		return s.typeBase + id
+	}
+	return requestURI(r)
+}
+
+// requestURI returns the URI of the request used as the problem type, "/" for a nil request
+func requestURI(r *http.Request) string {
+	if r == nil {
+		return "/"
+	}
+	return r.RequestURI
+}
@@ -0,0 +1,191 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cer "github.com/go-openapi/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestNewServer(t *testing.T) {
+	logger, logs := test.NewNullLogger()
+	var hooked []string
+
+	s := NewServer(
+		WithLogger(NewLogrusLogger(logger)),
+		WithContentType("application/problem+json"),
+		WithSupport(Support{Text: "Call us!"}),
+		WithHooks(HookFunc(func(r *http.Request, problem *models.ProblemDetails, err error) {
+			hooked = append(hooked, problem.Title)
+		})),
+	)
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/listings", nil)
+	s.ServeError(rr, r, errors.New("boom"))
+
+	EqualValues(t, http.StatusInternalServerError, rr.Code)
+	Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	Equal(t, []string{SystemFailure}, hooked)
+	Len(t, logs.AllEntries(), 1)
+	Equal(t, log.ErrorLevel, logs.LastEntry().Level)
+
+	problem := &models.ProblemDetails{}
+	NoError(t, problem.UnmarshalBinary(rr.Body.Bytes()))
+	Equal(t, "/listings", problem.Type)
+	Equal(t, "We are sorry, but there is an internal problem with the application! Call us!", problem.Detail)
+}
+
+func TestServer_WithExposure(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rr := httptest.NewRecorder()
+	NewServer().ServeError(rr, r, cer.NotFound("listing 42"))
+	Contains(t, rr.Body.String(), "listing 42")
+
+	rr = httptest.NewRecorder()
+	NewServer(WithExposure(HideErrors)).ServeError(rr, r, cer.NotFound("listing 42"))
+	EqualValues(t, http.StatusNotFound, rr.Code)
+	NotContains(t, rr.Body.String(), "listing 42")
+}
+
+func TestServer_WithAggregation(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	err := cer.CompositeValidationError(
+		cer.InvalidType("email", "body", "string", ""),
+		cer.InvalidType("limit", "query", "integer", ""),
+	)
+
+	rr := httptest.NewRecorder()
+	NewServer().ServeError(rr, r, err)
+	problem := &models.ProblemDetails{}
+	NoError(t, problem.UnmarshalBinary(rr.Body.Bytes()))
+	Equal(t, InvalidBodyParam, problem.Title)
+	Len(t, problem.InvalidParams, 1)
+
+	rr = httptest.NewRecorder()
+	NewServer(WithAggregation(AggregateAll)).ServeError(rr, r, err)
+	problem = &models.ProblemDetails{}
+	NoError(t, problem.UnmarshalBinary(rr.Body.Bytes()))
+	Equal(t, InvalidBodyParam, problem.Title)
+	Len(t, problem.InvalidParams, 2)
+}
+
+func TestServer_WithLocalizer(t *testing.T) {
+	catalog := NewCatalog(Entry{Title: ResourceNotFound, Status: http.StatusNotFound, Detail: "Not found!"})
+	localizer := LocalizerFunc(func(r *http.Request, problem *models.ProblemDetails) {
+		if r.Header.Get("Accept-Language") == "hr" {
+			problem.Detail = "Nije pronađeno!"
+		}
+	})
+	s := NewServer(WithCatalog(catalog), WithLocalizer(localizer), WithExposure(HideErrors))
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "hr")
+	s.ServeError(rr, r, cer.NotFound(""))
+
+	EqualValues(t, http.StatusNotFound, rr.Code)
+	Equal(t, `{"detail":"Nije pronađeno!","status":404,"title":"Resource not found!","type":"/"}`, rr.Body.String())
+}
+
+func TestServer_WithLocalizer_support(t *testing.T) {
+	support := Support{Text: "Contact support!", Email: "info@kviky.com"}
+	localizer := LocalizerFunc(func(_ *http.Request, problem *models.ProblemDetails) {
+		s, ok := problem.ProblemDetailsAdditionalProperties[ExtSupport].(Support)
+		if ok && strings.HasSuffix(problem.Detail, s.Text) {
+			problem.Detail = "Greška sustava! Kontaktirajte podršku!"
+		}
+	})
+	s := NewServer(WithLogger(NopLogger), WithSupport(support), WithLocalizer(localizer))
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("boom"))
+	EqualValues(t, http.StatusInternalServerError, rr.Code)
+	Contains(t, rr.Body.String(), `"detail":"Greška sustava! Kontaktirajte podršku!"`)
+}
+
+func TestServer_ProblemSupport(t *testing.T) {
+	localizer := LocalizerFunc(func(_ *http.Request, problem *models.ProblemDetails) {
+		problem.Title = "Usluga nije dostupna!"
+	})
+	s := NewServer(WithLogger(NopLogger), WithSupport(Support{Text: "PARTNER"}), WithLocalizer(localizer))
+
+	p := NewProblem(ServiceUnavailable)
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, httptest.NewRequest(http.MethodGet, "/", nil), fmt.Errorf("reserve: %w", p))
+
+	body := rr.Body.String()
+	Contains(t, body, `"title":"Usluga nije dostupna!"`)
+	Contains(t, body, `PARTNER"`)
+	NotContains(t, body, "info@kviky.com")
+	Equal(t, ServiceUnavailable, p.Title)
+	NotContains(t, p.Detail, "PARTNER")
+}
+
+func TestServer_head(t *testing.T) {
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodHead, "/listings", nil)
+	NewServer(WithLogger(NopLogger)).ServeError(rr, r, cer.MethodNotAllowed(http.MethodHead, []string{http.MethodGet}))
+
+	EqualValues(t, http.StatusMethodNotAllowed, rr.Code)
+	Equal(t, http.MethodGet, rr.Header().Get("Allow"))
+	Empty(t, rr.Body.String())
+}
+
+func TestServer_headProblem(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger), WithContentType("application/problem+json"))
+	r := httptest.NewRequest(http.MethodHead, "/listings/42", nil)
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, r, NewProblem(ListingNotFound))
+	EqualValues(t, http.StatusNotFound, rr.Code)
+	Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	Empty(t, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	s.ServeError(rr, r, cer.CompositeValidationError(cer.Required("id", "path", nil)))
+	EqualValues(t, http.StatusBadRequest, rr.Code)
+	Empty(t, rr.Body.String())
+}
+
+func TestServer_nilRequest(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger))
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, nil, NewProblem(ListingNotFound))
+	EqualValues(t, http.StatusNotFound, rr.Code)
+	Contains(t, rr.Body.String(), `"type":"/"`)
+
+	rr = httptest.NewRecorder()
+	s.ServeError(rr, nil, cer.MethodNotAllowed(http.MethodPost, []string{http.MethodGet}))
+	EqualValues(t, http.StatusMethodNotAllowed, rr.Code)
+	Contains(t, rr.Body.String(), `"type":"/"`)
+
+	rr = httptest.NewRecorder()
+	s.ServeError(rr, nil, cer.CompositeValidationError(cer.Required("id", "path", nil)))
+	EqualValues(t, http.StatusBadRequest, rr.Code)
+	Contains(t, rr.Body.String(), `"type":"/"`)
+}
+
+func TestWithTypeBase(t *testing.T) {
+	s := NewServer(WithLogger(NopLogger), WithTypeBase("https://api.kviky.com/problems/"))
+	r := httptest.NewRequest(http.MethodGet, "/listings/42", nil)
+
+	rr := httptest.NewRecorder()
+	s.ServeError(rr, r, NewProblem(ListingNotFound))
+	Contains(t, rr.Body.String(), `"type":"https://api.kviky.com/problems/listing.not_found"`)
+
+	rr = httptest.NewRecorder()
+	s.ServeError(rr, r, &Problem{ProblemDetails: &models.ProblemDetails{Title: "Boat sunk!", Status: 410}})
+	Contains(t, rr.Body.String(), `"type":"/listings/42"`)
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/Kviky/errors/models"
+)
+
+// ExtSupport is the name of the extension member carrying support information
+const ExtSupport = "support"
+
+// Support holds the contact information attached to every 5xx problem
+type Support struct {
+	// Text is appended to the detail of the problem
+	Text string `json:"message,omitempty"`
+	// Email is the support contact address
+	Email string `json:"email,omitempty"`
+	// URL is the support contact page
+	URL string `json:"url,omitempty"`
+	// StatusPage is an optional link to the service status page
+	StatusPage string `json:"statusPage,omitempty"`
+}
+
+var (
+	supportMu sync.RWMutex
+	support   = Support{
+		Text:  "Please try again later or contact support at info@kviky.com!",
+		Email: "info@kviky.com",
+	}
+)
+
+// SetSupport replaces the package-level support information used by
+// CreateProblemDetails and ServeError
+func SetSupport(s Support) {
+	supportMu.Lock()
+	defer supportMu.Unlock()
+	support = s
+}
+
+// DefaultSupport returns the package-level support information
+func DefaultSupport() Support {
+	supportMu.RLock()
+	defer supportMu.RUnlock()
+	return support
+}
+
+// IsZero reports whether no support information is set
+func (s Support) IsZero() bool {
+	return s == Support{}
+}
+
+// Apply appends the support text to the detail of a 5xx problem and adds
+// the support extension member. Problems with lower status and problems which
+// already carry support information are left intact.
+func (s Support) Apply(problem *models.ProblemDetails) {
+	if problem == nil || problem.Status < http.StatusInternalServerError || s.IsZero() {
+		return
+	}
+	if _, ok := problem.ProblemDetailsAdditionalProperties[ExtSupport]; ok {
+		return
+	}
+
+	if s.Text != "" {
+		if problem.Detail != "" {
+			problem.Detail += " "
+		}
+		problem.Detail += s.Text
+	}
+
+	if problem.ProblemDetailsAdditionalProperties == nil {
+		problem.ProblemDetailsAdditionalProperties = make(map[string]interface{})
+	}
+	problem.ProblemDetailsAdditionalProperties[ExtSupport] = s
+}
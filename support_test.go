@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+func TestSupport_Apply(t *testing.T) {
+	s := Support{
+		Text:       "Contact us at help@example.com!",
+		Email:      "help@example.com",
+		StatusPage: "https://status.example.com",
+	}
+
+	problem := &models.ProblemDetails{Status: http.StatusBadRequest, Detail: "Bad."}
+	s.Apply(problem)
+	Equal(t, "Bad.", problem.Detail)
+	Nil(t, problem.ProblemDetailsAdditionalProperties)
+
+	problem = &models.ProblemDetails{Status: http.StatusServiceUnavailable, Detail: "Down."}
+	s.Apply(problem)
+	Equal(t, "Down. Contact us at help@example.com!", problem.Detail)
+	Equal(t, s, problem.ProblemDetailsAdditionalProperties[ExtSupport])
+
+	binary, err := problem.MarshalBinary()
+	NoError(t, err)
+	JSONEq(t, `{"detail":"Down. Contact us at help@example.com!","status":503,"support":{"message":"Contact us at help@example.com!","email":"help@example.com","statusPage":"https://status.example.com"}}`, string(binary))
+
+	problem = &models.ProblemDetails{Status: http.StatusInternalServerError, Detail: "Failed."}
+	Support{}.Apply(problem)
+	Equal(t, "Failed.", problem.Detail)
+	Nil(t, problem.ProblemDetailsAdditionalProperties)
+
+	// support is applied once
+	Support{Text: "Call us!"}.Apply(problem)
+	Support{Text: "Call them!"}.Apply(problem)
+	Equal(t, "Failed. Call us!", problem.Detail)
+}
+
+func TestSetSupport(t *testing.T) {
+	defer SetSupport(DefaultSupport())
+
+	details := CreateProblemDetails(SystemFailure)
+	Equal(t, "We are sorry, but there is an internal problem with the application! Please try again later or contact support at info@kviky.com!", details.Detail)
+
+	SetSupport(Support{Text: "Call your charter partner.", URL: "https://partner.example.com/help"})
+	details = CreateProblemDetails(GatewayTimeout)
+	Equal(t, "The request is rejected due a request that has timed out at the HTTP client. Call your charter partner.", details.Detail)
+	Equal(t, "https://partner.example.com/help", details.ProblemDetailsAdditionalProperties[ExtSupport].(Support).URL)
+
+	details = CreateProblemDetails(ListingNotFound)
+	Equal(t, "The listing indicated in the request does not exist!", details.Detail)
+}
@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/Kviky/errors/models"
+)
+
+// problemPayloader is implemented by go-swagger generated client responses
+// with ProblemDetails payload of this package
+type problemPayloader interface {
+	GetPayload() *models.ProblemDetails
+}
+
+// statusCoder is implemented by responses generated by newer go-swagger versions
+type statusCoder interface {
+	Code() int
+}
+
+// responseStatusPattern matches the status in messages of generated responses,
+// e.g. "[GET /listings/{id}][404] getListingNotFound"
+var responseStatusPattern = regexp.MustCompile(`\]\[([1-5][0-9]{2})\] `)
+
+// FromClientError converts an error returned by a go-swagger generated client into
+// a Problem error when the response carries a ProblemDetails payload, so callers can
+// use errors.Is instead of type switches over the generated responses. The response
+// may be wrapped, e.g. with fmt.Errorf("%w"). The original error is kept as the cause.
+// Other errors are returned unchanged.
+func FromClientError(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(*Problem); ok {
+			return err
+		}
+
+		details := payloadOf(e)
+		if details == nil {
+			continue
+		}
+
+		p := DefaultCatalog.decoded(details, responseStatus(e))
+		if p.Status == 0 {
+			p.Status = http.StatusInternalServerError
+			if entry, ok := DefaultCatalog.lookupProblem(details); ok {
+				p.Status = entry.Status
+			}
+		}
+		p.cause = err
+		return p
+	}
+	return err
+}
+
+// responseStatus returns the status of a generated response, 0 when unknown.
+// Responses of older go-swagger versions have no Code method, their status is
+// read from the error message.
+func responseStatus(err error) int {
+	if c, ok := err.(statusCoder); ok {
+		return c.Code()
+	}
+	if m := responseStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		return status
+	}
+	return 0
+}
+
+// payloadOf returns the ProblemDetails payload of a generated response. Payloads
+// of ProblemDetails models generated in other packages are converted through JSON.
+func payloadOf(err error) *models.ProblemDetails {
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	if p, ok := err.(problemPayloader); ok {
+		if payload := p.GetPayload(); payload != nil && payload.Title != "" {
+			details := *payload
+			return &details
+		}
+		return nil
+	}
+
+	var payload reflect.Value
+	if m := v.MethodByName("GetPayload"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+		payload = m.Call(nil)[0]
+	} else {
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil
+		}
+		payload = v.FieldByName("Payload")
+	}
+
+	if !payload.IsValid() || payload.Kind() != reflect.Ptr || payload.IsNil() || payload.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	b, jsonErr := json.Marshal(payload.Interface())
+	if jsonErr != nil {
+		return nil
+	}
+	details := &models.ProblemDetails{}
+	if jsonErr = details.UnmarshalBinary(b); jsonErr != nil || details.Title == "" {
+		return nil
+	}
+	return details
+}
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+
+	"github.com/Kviky/errors/models"
+)
+
+// getListingNotFound mimics a response generated by go-swagger
+type getListingNotFound struct {
+	Payload *models.ProblemDetails
+}
+
+func (o *getListingNotFound) Error() string {
+	return fmt.Sprintf("[GET /listings/{id}][%d] getListingNotFound  %+v", 404, o.Payload)
+}
+
+func (o *getListingNotFound) GetPayload() *models.ProblemDetails {
+	return o.Payload
+}
+
+// foreignProblemDetails mimics ProblemDetails generated in a service's own models package
+type foreignProblemDetails struct {
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// createReservationConflict mimics a response of newer go-swagger versions
+type createReservationConflict struct {
+	Payload *foreignProblemDetails
+}
+
+func (o *createReservationConflict) Error() string {
+	return "[POST /reservations][409] createReservationConflict"
+}
+
+func (o *createReservationConflict) Code() int {
+	return 409
+}
+
+func TestFromClientError(t *testing.T) {
+	NoError(t, FromClientError(nil))
+
+	plain := errors.New("connection refused")
+	Equal(t, plain, FromClientError(plain))
+	Equal(t, &getListingNotFound{}, FromClientError(&getListingNotFound{}))
+	Equal(t, (*getListingNotFound)(nil), FromClientError((*getListingNotFound)(nil)))
+
+	generated := &getListingNotFound{Payload: CreateProblemDetails(ListingNotFound)}
+	err := FromClientError(generated)
+	True(t, Is(err, ListingNotFound))
+
+	var target *getListingNotFound
+	True(t, errors.As(err, &target))
+	Equal(t, generated, target)
+
+	err = FromClientError(&createReservationConflict{Payload: &foreignProblemDetails{Title: "Double booking!", Detail: "Boat is booked."}})
+	True(t, Is(err, "Double booking!"))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusConflict, p.Status)
+	Equal(t, "Boat is booked.", p.Detail)
+
+	Equal(t, p, FromClientError(p))
+	wrappedProblem := fmt.Errorf("reserve: %w", p)
+	Equal(t, wrappedProblem, FromClientError(wrappedProblem))
+}
+
+func TestFromClientError_wrapped(t *testing.T) {
+	generated := &getListingNotFound{Payload: &models.ProblemDetails{Title: "Boat not found!"}}
+	wrapped := fmt.Errorf("get listing 42: %w", generated)
+
+	err := FromClientError(wrapped)
+	True(t, Is(err, "Boat not found!"))
+	True(t, errors.Is(err, generated))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusNotFound, p.Status)
+	Equal(t, wrapped, p.Unwrap())
+
+	err = FromClientError(fmt.Errorf("create: %w", &createReservationConflict{Payload: &foreignProblemDetails{Title: "Double booking!"}}))
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusConflict, p.Status)
+}
+
+// listingGone mimics a response whose message has no status
+type listingGone struct {
+	Payload *models.ProblemDetails
+}
+
+func (o *listingGone) Error() string {
+	return "listingGone"
+}
+
+func TestFromClientError_status(t *testing.T) {
+	var p *Problem
+	err := FromClientError(&listingGone{Payload: &models.ProblemDetails{Title: ListingNotFound}})
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusNotFound, p.Status)
+
+	err = FromClientError(&listingGone{Payload: &models.ProblemDetails{Title: "Boat sunk!"}})
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusInternalServerError, p.Status)
+}
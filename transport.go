@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper returning Problem errors for 4xx and 5xx
+// responses of downstream services. The response body of such responses is closed.
+type Transport struct {
+	// Base is the underlying RoundTripper, http.DefaultTransport when nil
+	Base http.RoundTripper
+	// Catalog decodes the responses, DefaultCatalog when nil
+	Catalog *Catalog
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := t.Catalog
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+
+	err = catalog.FromResponse(resp)
+	if err == nil {
+		return resp, nil
+	}
+	if resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	return nil, err
+}
+
+// TranslateUpstream reports an upstream 5xx problem in err's chain as our own problem
+// with the upstream error attached as the cause, so it is served with our title and
+// instance. The translated problem keeps the retry semantics of the upstream one:
+// problems retryable by idempotent requests only, e.g. 504, become GatewayTimeout,
+// other retryable problems ServiceUnavailable and the rest SystemFailure.
+// Other errors are returned unchanged.
+//
+//	resp, err := client.Do(req)
+//	err = errors.TranslateUpstream(err)
+func TranslateUpstream(err error) error {
+	var p *Problem
+	if !errors.As(err, &p) || p.ProblemDetails == nil || p.Status < http.StatusInternalServerError {
+		return err
+	}
+
+	retry, _ := RetryOf(p)
+	switch {
+	case retry.Retryable && retry.IdempotentOnly:
+		return Wrap(err, GatewayTimeout)
+	case retry.Retryable:
+		return Wrap(err, ServiceUnavailable)
+	default:
+		return Wrap(err, SystemFailure)
+	}
+}
@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/listings/42":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		case "/listings/43":
+			NewServer(WithLogger(NopLogger)).ServeError(w, r, NewProblem(ListingNotFound))
+		default:
+			NewServer(WithLogger(NopLogger)).ServeError(w, r, errors.New("boom"))
+		}
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+
+	resp, err := client.Get(upstream.URL + "/listings/42")
+	NoError(t, err)
+	EqualValues(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	_, err = client.Get(upstream.URL + "/listings/43")
+	True(t, Is(err, ListingNotFound))
+
+	_, err = client.Get(upstream.URL + "/fail")
+	True(t, Is(err, SystemFailure))
+	False(t, Is(err, ServiceUnavailable))
+}
+
+// roundTripFunc is an adapter to use ordinary functions as http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTransport_noBody(t *testing.T) {
+	transport := &Transport{Base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+	})}
+
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil))
+	True(t, Is(err, ServiceUnavailable))
+}
+
+func TestTranslateUpstream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/listings/43":
+			NewServer(WithLogger(NopLogger)).ServeError(w, r, NewProblem(ListingNotFound))
+		case "/unavailable":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"title":"Pricing overloaded!","status":503}`))
+		case "/timeout":
+			w.WriteHeader(http.StatusGatewayTimeout)
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"title":"Pricing crashed!","status":500}`))
+		}
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	get := func(path string) error {
+		_, err := client.Get(upstream.URL + path)
+		return TranslateUpstream(err)
+	}
+
+	True(t, Is(get("/listings/43"), ListingNotFound))
+	False(t, Is(get("/listings/43"), ServiceUnavailable))
+
+	var translated, cause *Problem
+	err := get("/fail")
+	True(t, errors.As(err, &translated))
+	Equal(t, SystemFailure, translated.Title)
+	Equal(t, InstApi, translated.Instance)
+	True(t, errors.As(translated.Unwrap(), &cause))
+	Equal(t, "Pricing crashed!", cause.Title)
+	False(t, IsRetryable(err))
+
+	err = get("/unavailable")
+	True(t, errors.As(err, &translated))
+	Equal(t, ServiceUnavailable, translated.Title)
+	EqualValues(t, http.StatusServiceUnavailable, translated.Status)
+	True(t, errors.As(translated.Unwrap(), &cause))
+	Equal(t, "Pricing overloaded!", cause.Title)
+	True(t, IsRetryable(err))
+
+	err = get("/timeout")
+	True(t, Is(err, GatewayTimeout))
+	retry, _ := RetryOf(err)
+	True(t, retry.IdempotentOnly)
+
+	NoError(t, TranslateUpstream(nil))
+	plain := errors.New("connection refused")
+	Equal(t, plain, TranslateUpstream(plain))
+}
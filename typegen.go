@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// typeScriptModels are the TypeScript declarations of models.ProblemDetails and models.InvalidParam
+const typeScriptModels = `export interface InvalidParam {
+  param: string;
+  in?: string;
+  reason?: string;
+}
+
+export interface ProblemDetails {
+  id?: string;
+  type?: string;
+  title?: string;
+  status?: number;
+  detail?: string;
+  instance?: string;
+  code?: string;
+  invalidParams?: InvalidParam[];
+  [extension: string]: unknown;
+}
+`
+
+// WriteTypeScript writes TypeScript declarations of ProblemDetails, InvalidParam and
+// a Problems constant with the ID, title and status of every entry of the catalog. Frontends
+// branch on IDs with the ProblemID union instead of copying titles:
+//
+//	if (problem.id === Problems.ListingNotFound.id) {
+func (c *Catalog) WriteTypeScript(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "// Code generated by problemctl. DO NOT EDIT.\n\n")
+	fmt.Fprint(bw, typeScriptModels)
+
+	fmt.Fprint(bw, "\nexport const Problems = {\n")
+	for _, e := range c.Entries() {
+		id, _ := json.Marshal(e.ID)
+		title, _ := json.Marshal(e.Title)
+		fmt.Fprintf(bw, "  %s: { id: %s, title: %s, status: %d },\n", specName(e), id, title, e.Status)
+	}
+	fmt.Fprint(bw, "} as const;\n\n")
+
+	fmt.Fprint(bw, "export type ProblemName = keyof typeof Problems;\n")
+	fmt.Fprint(bw, "export type ProblemID = (typeof Problems)[ProblemName][\"id\"];\n")
+	fmt.Fprint(bw, "export type ProblemTitle = (typeof Problems)[ProblemName][\"title\"];\n")
+	return bw.Flush()
+}
+
+// JSONSchema generates a JSON Schema (draft-07) of ProblemDetails and InvalidParam
+// with a Problem definition matching the ID, title and status of every entry of the catalog
+func (c *Catalog) JSONSchema() map[string]interface{} {
+	entries := c.Entries()
+	problems := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		properties := map[string]interface{}{
+			"title":  map[string]interface{}{"const": e.Title},
+			"status": map[string]interface{}{"const": e.Status},
+		}
+		required := []string{"title", "status"}
+		if e.ID != "" {
+			properties["id"] = map[string]interface{}{"const": e.ID}
+			required = append(required, "id")
+		}
+		problems = append(problems, map[string]interface{}{
+			"title":      specName(e),
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		})
+	}
+
+	definitions := problemSchemas("#/definitions/")
+	definitions["Problem"] = map[string]interface{}{
+		"allOf": []interface{}{
+			map[string]interface{}{"$ref": "#/definitions/ProblemDetails"},
+			map[string]interface{}{"oneOf": problems},
+		},
+	}
+
+	return map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "Problems",
+		"definitions": definitions,
+		"$ref":        "#/definitions/Problem",
+	}
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_WriteTypeScript(t *testing.T) {
+	catalog := NewCatalog(
+		Entry{Name: "ListingNotFound", ID: "listing.not_found", Title: ListingNotFound, Status: 404},
+		Entry{Title: `Say "hi"!`, Status: 400},
+	)
+
+	var b bytes.Buffer
+	NoError(t, catalog.WriteTypeScript(&b))
+	ts := b.String()
+
+	True(t, strings.HasPrefix(ts, "// Code generated by problemctl. DO NOT EDIT."))
+	Contains(t, ts, "export interface ProblemDetails {")
+	Contains(t, ts, "  invalidParams?: InvalidParam[];")
+	Contains(t, ts, `  ListingNotFound: { id: "listing.not_found", title: "Listing not found!", status: 404 },`)
+	Contains(t, ts, `  SayHi: { id: "", title: "Say \"hi\"!", status: 400 },`)
+	Contains(t, ts, "export type ProblemID = (typeof Problems)[ProblemName][\"id\"];")
+	Contains(t, ts, "export type ProblemTitle = (typeof Problems)[ProblemName][\"title\"];")
+}
+
+func TestCatalog_JSONSchema(t *testing.T) {
+	schema := DefaultCatalog.JSONSchema()
+	Equal(t, "#/definitions/Problem", schema["$ref"])
+
+	problems := lookup(t, schema, "definitions", "Problem", "allOf").([]interface{})[1]
+	oneOf := lookup(t, problems, "oneOf").([]interface{})
+	Len(t, oneOf, len(DefaultCatalog.Entries()))
+	Equal(t, "AlreadyExists", lookup(t, oneOf[0], "title"))
+	Equal(t, AlreadyExists, lookup(t, oneOf[0], "properties", "title", "const"))
+	Equal(t, "resource.already_exists", lookup(t, oneOf[0], "properties", "id", "const"))
+
+	_, err := json.Marshal(schema)
+	NoError(t, err)
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/Kviky/errors/models"
+)
+
+// Validator accumulates InvalidParams of business rules, e.g. dates in the past
+// or exceeded capacity, and reports them as a single problem
+//
+//	v := errors.NewValidator(errors.MandatoryParamIncorrect)
+//	v.Required("name", body.Name)
+//	v.Range("capacity", body.Capacity, 1, 12)
+//	dates := v.Nested("dates")
+//	dates.Custom("from", body.From.After(time.Now()), "Must be in the future")
+//	return v.Err()
+type Validator struct {
+	title  string
+	prefix string
+	params *[]*models.InvalidParam
+}
+
+// NewValidator creates a Validator reporting the problem with given title
+func NewValidator(title string) *Validator {
+	return &Validator{
+		title:  title,
+		params: &[]*models.InvalidParam{},
+	}
+}
+
+// Nested returns a Validator of a nested object. Its fields are reported
+// with the path prefix, e.g. "dates.from".
+func (v *Validator) Nested(name string) *Validator {
+	return &Validator{
+		title:  v.title,
+		prefix: v.path(name) + ".",
+		params: v.params,
+	}
+}
+
+// Index returns a Validator of an array item. Its fields are reported
+// with the path prefix, e.g. "guests[1].email".
+func (v *Validator) Index(name string, i int) *Validator {
+	return &Validator{
+		title:  v.title,
+		prefix: fmt.Sprintf("%s[%d].", v.path(name), i),
+		params: v.params,
+	}
+}
+
+// Add adds an InvalidParam with given reason
+func (v *Validator) Add(field, reason string) {
+	v.AddParam(&models.InvalidParam{Param: &field, Reason: reason})
+}
+
+// AddParam adds an InvalidParam created elsewhere, e.g. by NewImageSizeError.
+// Its name is prefixed with the path of nested validators.
+func (v *Validator) AddParam(param *models.InvalidParam) {
+	p := *param
+	if p.Param != nil {
+		path := v.path(*p.Param)
+		p.Param = &path
+	}
+	*v.params = append(*v.params, &p)
+}
+
+// Required checks the value is not the zero value of its type
+func (v *Validator) Required(field string, value interface{}) bool {
+	if swag.IsZero(value) {
+		v.AddParam(NewMissingParam(field))
+		return false
+	}
+	return true
+}
+
+// MaxLength checks the value has at most max characters
+func (v *Validator) MaxLength(field, value string, max int) bool {
+	return v.Custom(field, utf8.RuneCountInString(value) <= max, fmt.Sprintf("Maximum length is %d", max))
+}
+
+// Range checks the value is between min and max inclusive
+func (v *Validator) Range(field string, value, min, max int64) bool {
+	return v.Custom(field, value >= min && value <= max, fmt.Sprintf("Must be between %d and %d", min, max))
+}
+
+// OneOf checks the value is one of the allowed values
+func (v *Validator) OneOf(field, value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return v.Custom(field, false, fmt.Sprintf("Must be one of %s", strings.Join(allowed, ", ")))
+}
+
+// Custom adds an InvalidParam with given reason unless ok
+func (v *Validator) Custom(field string, ok bool, reason string) bool {
+	if !ok {
+		v.Add(field, reason)
+	}
+	return ok
+}
+
+// Valid reports whether no InvalidParam was collected
+func (v *Validator) Valid() bool {
+	return len(*v.params) == 0
+}
+
+// InvalidParams returns all collected InvalidParams
+func (v *Validator) InvalidParams() []*models.InvalidParam {
+	return *v.params
+}
+
+// Err returns the problem with all collected InvalidParams or nil
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+
+	p := NewProblem(v.title)
+	p.InvalidParams = append([]*models.InvalidParam(nil), *v.params...)
+	return p
+}
+
+func (v *Validator) path(field string) string {
+	return v.prefix + field
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+)
+
+func TestValidator(t *testing.T) {
+	v := NewValidator(MandatoryParamIncorrect)
+	True(t, v.Required("name", "Blue Lagoon"))
+	True(t, v.MaxLength("name", "Blue Lagoon", 20))
+	True(t, v.Range("capacity", 8, 1, 12))
+	True(t, v.OneOf("type", "sailboat", "sailboat", "catamaran"))
+	True(t, v.Custom("dates", true, "Dates overlap"))
+	True(t, v.Valid())
+	NoError(t, v.Err())
+
+	False(t, v.Required("name", ""))
+	False(t, v.MaxLength("description", "Čamac", 4))
+	False(t, v.Range("capacity", 13, 1, 12))
+	False(t, v.OneOf("type", "yacht", "sailboat", "catamaran"))
+
+	dates := v.Nested("dates")
+	False(t, dates.Custom("from", false, "Must be in the future"))
+	False(t, v.Index("guests", 1).Required("email", nil))
+
+	err := v.Err()
+	True(t, Is(err, MandatoryParamIncorrect))
+
+	var p *Problem
+	True(t, errors.As(err, &p))
+	EqualValues(t, http.StatusBadRequest, p.Status)
+
+	var got [][2]string
+	for _, param := range p.InvalidParams {
+		got = append(got, [2]string{*param.Param, param.Reason})
+	}
+	Equal(t, [][2]string{
+		{"name", "Param missing"},
+		{"description", "Maximum length is 4"},
+		{"capacity", "Must be between 1 and 12"},
+		{"type", "Must be one of sailboat, catamaran"},
+		{"dates.from", "Must be in the future"},
+		{"guests[1].email", "Param missing"},
+	}, got)
+	Len(t, v.InvalidParams(), 6)
+}
+
+func TestValidator_Err(t *testing.T) {
+	v := NewValidator(InvalidBodyParam)
+	v.Add("body", "Overlapping reservations")
+
+	err := v.Err()
+	True(t, Is(err, InvalidBodyParam))
+
+	v.Add("body", "Capacity exceeded")
+	var p *Problem
+	True(t, errors.As(err, &p))
+	Len(t, p.InvalidParams, 1)
+}
+
+func TestValidator_AddParam(t *testing.T) {
+	v := NewValidator(ImageInvalid)
+	param := NewImageSizePxError("width", 100)
+	v.Nested("cover").AddParam(param)
+
+	Equal(t, "width", *param.Param)
+	Equal(t, "cover.width", *v.InvalidParams()[0].Param)
+	Equal(t, "Maximum width is 100px", v.InvalidParams()[0].Reason)
+}